@@ -5,10 +5,13 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/ejagojo/SentryScan/internal/alert"
 	"github.com/ejagojo/SentryScan/internal/baseline"
+	"github.com/ejagojo/SentryScan/internal/cache"
+	"github.com/ejagojo/SentryScan/internal/fingerprint"
 	"github.com/ejagojo/SentryScan/internal/image"
 	"github.com/ejagojo/SentryScan/internal/output"
 	"github.com/ejagojo/SentryScan/internal/scanner"
@@ -17,23 +20,44 @@ import (
 )
 
 var (
-	version       = "dev" // Set by ldflags
-	configPath    string
-	outputType    string
-	outputFile    string
-	noFail        bool
-	threads       int
-	since         string
-	branch        string
-	commitRange   string
-	includeExt    []string
-	excludeExt    []string
-	imageRef      string
-	compareRef    string
-	noBaseline    bool
-	webhookURL    string
-	webhookSecret string
-	severity      string
+	version          = "dev" // Set by ldflags
+	configPath       string
+	outputType       string
+	outputFile       string
+	noFail           bool
+	threads          int
+	since            string
+	branch           string
+	commitRange      string
+	includeExt       []string
+	excludeExt       []string
+	imageRef         string
+	platform         string
+	compareRef       string
+	noBaseline       bool
+	webhookURL       string
+	webhookSecret    string
+	severity         string
+	blame            bool
+	blameRev         string
+	respectGitignore bool
+	extraIgnoreFiles []string
+	remoteURL        string
+	remoteFilter     string
+	remoteSSHKey     string
+	remoteSSHKeyPass string
+	remoteToken      string
+	noCache          bool
+	cachePath        string
+	cachePruneAge    time.Duration
+	historyDepth     int
+	shallowPack      bool
+	verbose          bool
+	entropyThreshold float64
+	minTokenLen      int
+	fingerprintOut   string
+	queuePath        string
+	alertReplaySince time.Duration
 )
 
 // exitWith is a function that can be replaced in tests
@@ -43,16 +67,23 @@ var exitWith = func(err error, findings []scanner.Finding, suppressed bool) {
 		os.Exit(1)
 	}
 
+	informational := 0
 	if !noFail {
 		for _, f := range findings {
-			if f.Severity == "critical" || f.Severity == "high" {
+			if f.Violations != nil && f.Violations.Violation {
 				if suppressed {
 					os.Exit(5)
 				}
 				os.Exit(3)
 			}
+			if f.Severity == "critical" || f.Severity == "high" {
+				informational++
+			}
 		}
 	}
+	if informational > 0 {
+		fmt.Fprintf(os.Stderr, "%d high-severity finding(s) reported as informational (no matching watch rule)\n", informational)
+	}
 	os.Exit(0)
 }
 
@@ -75,8 +106,8 @@ var scanCmd = &cobra.Command{
 		}
 
 		// Validate required arguments
-		if len(args) == 0 && imageRef == "" {
-			return fmt.Errorf("either --image or paths must be specified")
+		if len(args) == 0 && imageRef == "" && remoteURL == "" {
+			return fmt.Errorf("either --image, --remote, or paths must be specified")
 		}
 
 		return nil
@@ -92,12 +123,14 @@ var scanCmd = &cobra.Command{
 		}
 
 		flags := map[string]interface{}{
-			"image":          imageRef,
-			"compare":        compareRef,
-			"no-baseline":    noBaseline,
-			"webhook-url":    webhookURL,
-			"webhook-secret": webhookSecret,
-			"severity":       severity,
+			"image":             imageRef,
+			"compare":           compareRef,
+			"no-baseline":       noBaseline,
+			"webhook-url":       webhookURL,
+			"webhook-secret":    webhookSecret,
+			"severity":          severity,
+			"entropy-threshold": entropyThreshold,
+			"min-token-len":     minTokenLen,
 		}
 		config = scanner.MergeConfig(config, flags)
 
@@ -107,6 +140,7 @@ var scanCmd = &cobra.Command{
 			if err != nil {
 				return fmt.Errorf("failed to create image scanner: %w", err)
 			}
+			imgScanner.Platform = platform
 
 			imgFindings, err := imgScanner.Scan(context.Background(), config.Image, config.CompareImage)
 			if err != nil {
@@ -115,9 +149,15 @@ var scanCmd = &cobra.Command{
 			findings = append(findings, imgFindings...)
 		}
 
-		// Scan files/repo if paths specified
-		if len(args) > 0 {
+		// Scan files/repo if paths or a remote were specified
+		if len(args) > 0 || remoteURL != "" {
 			s := scanner.NewScanner()
+			if !noCache {
+				digest := cache.RulesDigest(s.Rules())
+				if fc, err := cache.Open(cachePath, digest); err == nil {
+					s.SetCache(fc)
+				}
+			}
 			opts := scanner.ScannerOptions{
 				Threads:     threads,
 				Since:       since,
@@ -125,6 +165,44 @@ var scanCmd = &cobra.Command{
 				CommitRange: commitRange,
 				IncludeExt:  includeExt,
 				ExcludeExt:  excludeExt,
+				Blame:       blame,
+				BlameRev:    blameRev,
+
+				RespectGitignore: respectGitignore,
+				ExtraIgnoreFiles: extraIgnoreFiles,
+
+				Depth:       historyDepth,
+				ShallowPack: shallowPack,
+
+				EntropyThreshold: config.EntropyThreshold,
+				MinTokenLen:      config.MinTokenLen,
+
+				BlacklistedStrings:    config.BlacklistedStrings,
+				BlacklistedExtensions: config.BlacklistedExtensions,
+				BlacklistedPaths:      config.BlacklistedPaths,
+				ExcludePaths:          config.ExcludePaths,
+			}
+
+			if remoteURL != "" {
+				opts.Remote = &scanner.RemoteOptions{
+					URL:            remoteURL,
+					From:           since,
+					To:             branch,
+					Filter:         remoteFilter,
+					SSHKeyPath:     remoteSSHKey,
+					SSHKeyPassword: remoteSSHKeyPass,
+					Token:          remoteToken,
+				}
+			}
+
+			if config.Signatures != nil {
+				opts.Signatures = &scanner.SignatureOptions{
+					Require:          config.Signatures.Require,
+					AllowedSigners:   config.Signatures.AllowedSigners,
+					AllowedKeyIDs:    config.Signatures.AllowedKeyIDs,
+					SeverityUnsigned: config.Signatures.SeverityUnsigned,
+					SeverityBad:      config.Signatures.SeverityBad,
+				}
 			}
 
 			fileFindings, err := s.Run(context.Background(), opts, args...)
@@ -146,6 +224,11 @@ var scanCmd = &cobra.Command{
 			suppressed = len(findings) < originalCount
 		}
 
+		// Classify findings as policy-breaking violations vs informational
+		// vulnerabilities per config.Watches, so exitWith and the output writers can tell
+		// the two apart.
+		config.ClassifyViolations(findings)
+
 		// Send webhook if configured
 		if config.WebhookURL != "" && len(findings) > 0 {
 			wh := alert.NewWebhook(config.WebhookURL, config.WebhookSecret)
@@ -157,11 +240,39 @@ var scanCmd = &cobra.Command{
 				GitRef:      branch,
 				GeneratedAt: time.Now(),
 			}
-			if err := wh.Send(payload); err != nil {
+			if err := wh.Send(context.Background(), payload); err != nil {
 				return fmt.Errorf("failed to send webhook: %w", err)
 			}
 		}
 
+		// Fan out to any additionally configured alert transports (Slack, PagerDuty, a local
+		// file sink, ...) declared under the config file's `alerts:` block.
+		if len(findings) > 0 {
+			alertsConfig, err := alert.LoadAlertsConfig(configPath)
+			if err != nil {
+				return fmt.Errorf("failed to load alerts config: %w", err)
+			}
+			if len(alertsConfig.Transports) > 0 {
+				dispatcher, err := alert.BuildDispatcher(alertsConfig)
+				if err != nil {
+					return fmt.Errorf("failed to configure alert transports: %w", err)
+				}
+				payload := &alert.Payload{
+					RunID:       fmt.Sprintf("run-%d", time.Now().Unix()),
+					Summary:     fmt.Sprintf("Found %d security findings", len(findings)),
+					Findings:    findings,
+					Repo:        args[0],
+					GitRef:      branch,
+					GeneratedAt: time.Now(),
+				}
+				for _, result := range dispatcher.Dispatch(context.Background(), payload) {
+					if result.Err != nil {
+						return fmt.Errorf("alert transport %s failed: %w", result.Transport, result.Err)
+					}
+				}
+			}
+		}
+
 		// Determine output writer
 		var w io.Writer = os.Stdout
 		if outputFile != "" {
@@ -174,7 +285,7 @@ var scanCmd = &cobra.Command{
 		}
 
 		// Write findings
-		if err := output.WriteFindings(findings, output.OutputType(outputType), w); err != nil {
+		if err := output.WriteFindings(findings, output.OutputType(outputType), w, verbose); err != nil {
 			return fmt.Errorf("failed to write findings: %w", err)
 		}
 
@@ -232,6 +343,115 @@ var baselineListCmd = &cobra.Command{
 	},
 }
 
+var fingerprintCmd = &cobra.Command{
+	Use:   "fingerprint <path>",
+	Short: "Emit WFP-style file fingerprints for a path",
+	Long:  `Walk <path> and write one whole-file MD5 plus per-line MD5s (of normalized content) per file, in the same format baseline suppressions are keyed against.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root := args[0]
+
+		var fingerprints []*fingerprint.FileFingerprint
+		err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if d.Name() == ".git" || d.Name() == "vendor" || d.Name() == "node_modules" {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+
+			fp, fpErr := fingerprint.Compute(path)
+			if fpErr != nil {
+				return fpErr
+			}
+			fingerprints = append(fingerprints, fp)
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to fingerprint %s: %w", root, err)
+		}
+
+		var w io.Writer = os.Stdout
+		if fingerprintOut != "" {
+			f, err := os.Create(fingerprintOut)
+			if err != nil {
+				return fmt.Errorf("failed to create output file: %w", err)
+			}
+			defer f.Close()
+			w = f
+		}
+
+		return fingerprint.WriteWFP(w, fingerprints)
+	},
+}
+
+var alertCmd = &cobra.Command{
+	Use:   "alert",
+	Short: "Inspect or maintain the durable alert delivery queue",
+	Long:  `Manage the durable queue backing Queue-wrapped alert transports (see internal/alert.Queue).`,
+}
+
+var alertReplayCmd = &cobra.Command{
+	Use:   "replay",
+	Short: "Move dead-lettered alerts back onto the retry schedule",
+	Long:  `Requeue every alert dead-lettered within --since so the next Queue.Drain retries delivering it.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := alert.NewJSONLQueueStore(queuePath)
+		if err != nil {
+			return fmt.Errorf("failed to open alert queue: %w", err)
+		}
+
+		replayed, err := alert.ReplayDeadLetters(store, time.Now().Add(-alertReplaySince))
+		if err != nil {
+			return fmt.Errorf("failed to replay dead-lettered alerts: %w", err)
+		}
+
+		fmt.Printf("replayed %d alert(s)\n", replayed)
+		return nil
+	},
+}
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Inspect or maintain the content-addressed findings cache",
+	Long:  `Manage the findings cache used to skip re-scanning unchanged blobs across runs.`,
+}
+
+var cacheStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show findings cache statistics",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := cache.Open(cachePath, cache.RulesDigest(scanner.NewScanner().Rules()))
+		if err != nil {
+			return fmt.Errorf("failed to open cache: %w", err)
+		}
+		stats := c.Stats()
+		fmt.Printf("entries: %d\nstale (rule set changed): %d\noldest: %s\nnewest: %s\n",
+			stats.Entries, stats.StaleDigest, stats.OldestScan, stats.NewestScan)
+		return nil
+	},
+}
+
+var cachePruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove stale or expired entries from the findings cache",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := cache.Open(cachePath, cache.RulesDigest(scanner.NewScanner().Rules()))
+		if err != nil {
+			return fmt.Errorf("failed to open cache: %w", err)
+		}
+		removed, err := c.Prune(cachePruneAge)
+		if err != nil {
+			return fmt.Errorf("failed to prune cache: %w", err)
+		}
+		fmt.Printf("removed %d entries\n", removed)
+		return nil
+	},
+}
+
 func init() {
 	configPath = scanner.DefaultConfigPath()
 
@@ -240,6 +460,9 @@ func init() {
 	scanCmd.Flags().StringVarP(&outputType, "type", "t", "console", "output type (console, json, sarif)")
 	scanCmd.Flags().StringVarP(&outputFile, "out", "o", "", "output file (default: stdout)")
 	scanCmd.Flags().BoolVar(&noFail, "no-fail", false, "don't fail on high severity findings")
+	scanCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "render remediation guidance below each console finding")
+	scanCmd.Flags().Float64Var(&entropyThreshold, "entropy-threshold", 0, "Shannon entropy threshold for the generic-high-entropy detector (default 4.5 base64 / 3.5 hex)")
+	scanCmd.Flags().IntVar(&minTokenLen, "min-token-len", 0, "minimum token length considered by the generic-high-entropy detector (default 20)")
 	scanCmd.Flags().IntVar(&threads, "threads", 4, "number of concurrent scanning threads")
 	scanCmd.Flags().StringVar(&since, "since", "", "scan changes since commit")
 	scanCmd.Flags().StringVar(&branch, "branch", "", "scan specific branch")
@@ -247,18 +470,49 @@ func init() {
 	scanCmd.Flags().StringSliceVar(&includeExt, "include-ext", nil, "include files with these extensions")
 	scanCmd.Flags().StringSliceVar(&excludeExt, "exclude-ext", nil, "exclude files with these extensions")
 	scanCmd.Flags().StringVar(&imageRef, "image", "", "scan container image")
+	scanCmd.Flags().StringVar(&platform, "platform", "", "platform to select from a multi-arch image (e.g. linux/amd64), defaults to linux/amd64")
 	scanCmd.Flags().StringVar(&compareRef, "compare", "", "compare with base image")
 	scanCmd.Flags().BoolVar(&noBaseline, "no-baseline", false, "ignore baseline suppressions")
 	scanCmd.Flags().StringVar(&webhookURL, "webhook-url", "", "webhook URL for alerts")
 	scanCmd.Flags().StringVar(&webhookSecret, "webhook-secret", "", "webhook secret for signing")
 	scanCmd.Flags().StringVar(&severity, "severity", "high", "minimum severity threshold")
+	scanCmd.Flags().BoolVar(&blame, "blame", false, "attribute findings to the commit that introduced them via git blame")
+	scanCmd.Flags().StringVar(&blameRev, "blame-rev", "HEAD", "ref to run --blame against")
+	scanCmd.Flags().BoolVar(&respectGitignore, "respect-gitignore", true, "prune files matched by .gitignore/.gitattributes during the walk")
+	scanCmd.Flags().StringSliceVar(&extraIgnoreFiles, "extra-ignore-file", nil, "additional gitignore-style pattern files to apply")
+	scanCmd.Flags().StringVar(&remoteURL, "remote", "", "scan a remote repository via partial clone instead of a local checkout")
+	scanCmd.Flags().StringVar(&remoteFilter, "remote-filter", "blob:none", "partial clone filter to request for --remote (e.g. blob:none, blob:limit=1m)")
+	scanCmd.Flags().StringVar(&remoteSSHKey, "remote-ssh-key", "", "private key file to authenticate --remote over SSH")
+	scanCmd.Flags().StringVar(&remoteSSHKeyPass, "remote-ssh-key-password", "", "passphrase for --remote-ssh-key")
+	scanCmd.Flags().StringVar(&remoteToken, "remote-token", "", "bearer/PAT token to authenticate --remote over HTTPS")
+	scanCmd.Flags().BoolVar(&noCache, "no-cache", false, "disable the content-addressed findings cache")
+	scanCmd.Flags().StringVar(&cachePath, "cache-path", cache.DefaultPath(), "path to the findings cache")
+	scanCmd.Flags().IntVar(&historyDepth, "depth", 0, "limit history scanning to this many commits back from the starting rev (0 = no limit)")
+	scanCmd.Flags().BoolVar(&shallowPack, "shallow-pack", false, "read packfiles directly instead of materializing blobs (reserved, currently a no-op)")
+
+	// Fingerprint command
+	fingerprintCmd.Flags().StringVarP(&fingerprintOut, "out", "o", "", "output file (default: stdout)")
+
+	// Cache commands
+	cacheCmd.PersistentFlags().StringVar(&cachePath, "cache-path", cache.DefaultPath(), "path to the findings cache")
+	cachePruneCmd.Flags().DurationVar(&cachePruneAge, "older-than", 30*24*time.Hour, "remove cache entries older than this duration")
+	cacheCmd.AddCommand(cacheStatsCmd)
+	cacheCmd.AddCommand(cachePruneCmd)
 
 	// Baseline commands
 	baselineCmd.AddCommand(baselineAddCmd)
 	baselineCmd.AddCommand(baselineListCmd)
 
+	// Alert commands
+	alertCmd.PersistentFlags().StringVar(&queuePath, "queue-path", alert.DefaultQueueDir(), "path to the durable alert delivery queue")
+	alertReplayCmd.Flags().DurationVar(&alertReplaySince, "since", 7*24*time.Hour, "replay alerts dead-lettered within this duration")
+	alertCmd.AddCommand(alertReplayCmd)
+
 	rootCmd.AddCommand(scanCmd)
 	rootCmd.AddCommand(baselineCmd)
+	rootCmd.AddCommand(cacheCmd)
+	rootCmd.AddCommand(fingerprintCmd)
+	rootCmd.AddCommand(alertCmd)
 }
 
 func main() {
@@ -0,0 +1,167 @@
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Authorizer governs whether and how a Webhook may talk to its destination, following the same
+// "pre-authorize" handshake GitLab Workhorse uses before accepting an upload: a central
+// control-plane decides policy (severity floor, payload limits, signing secret, rate limits) so
+// many CI runners can share one webhook destination without each holding its own long-lived
+// secret. Webhook.Send calls Authorize once per destination and caches the result until it
+// expires (see HTTPAuthorizer), refreshing early if the destination starts returning 401.
+type Authorizer interface {
+	// Authorize returns the policy a Webhook must apply when sending for repo/runID, or an error
+	// if authorization could not be obtained. A nil *Authorization with a nil error means "no
+	// policy to enforce" - the webhook's own configured secret and defaults apply unchanged.
+	Authorize(ctx context.Context, repo, runID string) (*Authorization, error)
+}
+
+// Authorization is the per-destination policy an Authorizer hands back: the severity floor and
+// payload ceiling a destination is willing to receive, the HMAC secret and algorithm it expects,
+// where oversized findings should be offloaded to (see alert.ObjectStore), and the rate budget
+// the destination has granted this caller.
+type Authorization struct {
+	AllowedSeverityFloor string    `json:"allowed_severity_floor"`
+	MaxPayloadBytes      int64     `json:"max_payload_bytes"`
+	HMACAlgorithm        string    `json:"hmac_algorithm"` // "sha256" or "sha512"
+	Secret               string    `json:"secret"`
+	UploadPath           string    `json:"upload_path,omitempty"`
+	RateLimit            RateLimit `json:"rate_limit"`
+	ExpiresAt            time.Time `json:"expires_at"`
+}
+
+// RateLimit is the token-bucket budget an Authorization grants for one destination.
+type RateLimit struct {
+	Rate  float64 `json:"rate"`  // tokens per second
+	Burst int     `json:"burst"` // bucket capacity
+}
+
+// hmacAlgorithm returns the crypto/sha256-or-sha512-backed Signature.Algorithm value a's
+// HMACAlgorithm selects, defaulting to HMAC-SHA256 when a is nil or leaves it unset.
+func (a *Authorization) hmacAlgorithm() string {
+	if a != nil && strings.EqualFold(a.HMACAlgorithm, "sha512") {
+		return "HMAC-SHA512"
+	}
+	return "HMAC-SHA256"
+}
+
+// StaticAuthorizer always returns the fixed Authorization it was constructed with (which may be
+// nil), without ever making a network call. It's the default used when a Webhook is built
+// without WithAuthorizer, preserving the pre-handshake behavior - the webhook's own configured
+// secret and thresholds govern every Send - for callers that don't run a control-plane server.
+type StaticAuthorizer struct {
+	auth *Authorization
+}
+
+// NewStaticAuthorizer creates a StaticAuthorizer that always returns auth (nil for the
+// no-op/current-behavior case).
+func NewStaticAuthorizer(auth *Authorization) *StaticAuthorizer {
+	return &StaticAuthorizer{auth: auth}
+}
+
+// Authorize implements Authorizer.
+func (s *StaticAuthorizer) Authorize(ctx context.Context, repo, runID string) (*Authorization, error) {
+	return s.auth, nil
+}
+
+// HTTPAuthorizer fetches per-destination policy from a control-plane server's
+// "GET {base}/authorize?repo=...&run_id=..." endpoint, caching each response until its
+// ExpiresAt. Webhook.Send calls Invalidate after a 401 so a rotated secret is picked up on the
+// very next attempt instead of waiting out the cached expiry.
+type HTTPAuthorizer struct {
+	baseURL string
+	client  *http.Client
+
+	mu    sync.Mutex
+	cache map[string]*Authorization
+}
+
+// NewHTTPAuthorizer creates an HTTPAuthorizer against the control-plane server at baseURL (no
+// trailing slash required).
+func NewHTTPAuthorizer(baseURL string) *HTTPAuthorizer {
+	return &HTTPAuthorizer{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  &http.Client{Timeout: 10 * time.Second},
+		cache:   make(map[string]*Authorization),
+	}
+}
+
+// cacheKey identifies one (repo, runID) authorization in h.cache.
+func cacheKey(repo, runID string) string {
+	return repo + ":" + runID
+}
+
+// Authorize implements Authorizer, returning the cached Authorization for (repo, runID) if it
+// hasn't expired yet, and fetching a fresh one from the control plane otherwise.
+func (h *HTTPAuthorizer) Authorize(ctx context.Context, repo, runID string) (*Authorization, error) {
+	key := cacheKey(repo, runID)
+
+	h.mu.Lock()
+	cached, ok := h.cache[key]
+	h.mu.Unlock()
+	if ok && time.Now().Before(cached.ExpiresAt) {
+		return cached, nil
+	}
+
+	auth, err := h.fetch(ctx, repo, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	h.mu.Lock()
+	h.cache[key] = auth
+	h.mu.Unlock()
+
+	return auth, nil
+}
+
+// Invalidate drops the cached Authorization for (repo, runID), if any, forcing the next
+// Authorize call to re-fetch it from the control plane.
+func (h *HTTPAuthorizer) Invalidate(repo, runID string) {
+	h.mu.Lock()
+	delete(h.cache, cacheKey(repo, runID))
+	h.mu.Unlock()
+}
+
+// fetch performs the authorize handshake against the control plane, unconditionally, bypassing
+// the cache.
+func (h *HTTPAuthorizer) fetch(ctx context.Context, repo, runID string) (*Authorization, error) {
+	endpoint := fmt.Sprintf("%s/authorize?repo=%s&run_id=%s", h.baseURL, url.QueryEscape(repo), url.QueryEscape(runID))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorize request: %v", err)
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach authorization server: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("authorization server returned status %d", resp.StatusCode)
+	}
+
+	var auth Authorization
+	if err := json.NewDecoder(resp.Body).Decode(&auth); err != nil {
+		return nil, fmt.Errorf("failed to decode authorization response: %v", err)
+	}
+
+	return &auth, nil
+}
+
+// invalidator is implemented by Authorizers that can drop a cached Authorization early.
+// Webhook.Send type-asserts on it after a 401 so refreshing works with HTTPAuthorizer without
+// forcing every Authorizer implementation (e.g. StaticAuthorizer) to support it.
+type invalidator interface {
+	Invalidate(repo, runID string)
+}
@@ -0,0 +1,176 @@
+package alert
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ejagojo/SentryScan/internal/scanner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaticAuthorizer_ReturnsFixedValue(t *testing.T) {
+	auth := &Authorization{AllowedSeverityFloor: "high"}
+	s := NewStaticAuthorizer(auth)
+
+	got, err := s.Authorize(context.Background(), "repo", "run-1")
+	require.NoError(t, err)
+	assert.Same(t, auth, got)
+}
+
+func TestHTTPAuthorizer_CachesUntilExpiry(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/authorize", r.URL.Path)
+		assert.Equal(t, "acme/widgets", r.URL.Query().Get("repo"))
+		assert.Equal(t, "run-1", r.URL.Query().Get("run_id"))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"allowed_severity_floor":"low","max_payload_bytes":1048576,"hmac_algorithm":"sha256","secret":"rotating-secret","expires_at":"` + time.Now().Add(time.Hour).Format(time.RFC3339) + `"}`))
+	}))
+	defer server.Close()
+
+	h := NewHTTPAuthorizer(server.URL)
+
+	auth, err := h.Authorize(context.Background(), "acme/widgets", "run-1")
+	require.NoError(t, err)
+	assert.Equal(t, "rotating-secret", auth.Secret)
+
+	auth2, err := h.Authorize(context.Background(), "acme/widgets", "run-1")
+	require.NoError(t, err)
+	assert.Same(t, auth, auth2)
+	assert.Equal(t, 1, requests, "second Authorize call should be served from cache")
+}
+
+func TestHTTPAuthorizer_InvalidateForcesRefetch(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"expires_at":"` + time.Now().Add(time.Hour).Format(time.RFC3339) + `"}`))
+	}))
+	defer server.Close()
+
+	h := NewHTTPAuthorizer(server.URL)
+
+	_, err := h.Authorize(context.Background(), "repo", "run-1")
+	require.NoError(t, err)
+
+	h.Invalidate("repo", "run-1")
+
+	_, err = h.Authorize(context.Background(), "repo", "run-1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, requests)
+}
+
+func TestHTTPAuthorizer_Non2xxIsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	h := NewHTTPAuthorizer(server.URL)
+	_, err := h.Authorize(context.Background(), "repo", "run-1")
+	assert.Error(t, err)
+}
+
+func TestWebhook_AuthorizerSuppressesBelowSeverityFloor(t *testing.T) {
+	delivered := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		delivered = true
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	wh := NewWebhook(server.URL, "test-secret", WithAuthorizer(NewStaticAuthorizer(&Authorization{
+		AllowedSeverityFloor: "critical",
+	})))
+
+	payload := &Payload{
+		RunID:       "run-1",
+		Repo:        "acme/widgets",
+		GitRef:      "main",
+		GeneratedAt: time.Now(),
+		Findings:    []scanner.Finding{{RuleID: "low-sev", Severity: "low"}},
+	}
+
+	err := wh.Send(context.Background(), payload)
+	require.NoError(t, err)
+	assert.False(t, delivered, "finding below the authorized severity floor should not be sent")
+}
+
+func TestWebhook_AuthorizerSecretSignsPayload(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	wh := NewWebhook(server.URL, "local-secret", WithAuthorizer(NewStaticAuthorizer(&Authorization{
+		Secret: "control-plane-secret",
+	})))
+
+	payload := &Payload{
+		RunID:       "run-1",
+		Repo:        "acme/widgets",
+		GitRef:      "main",
+		GeneratedAt: time.Now(),
+	}
+
+	require.NoError(t, wh.Send(context.Background(), payload))
+
+	sig, err := wh.signPayloadWithSecret(payload, []byte("control-plane-secret"), "HMAC-SHA256")
+	require.NoError(t, err)
+	assert.Equal(t, sig.Value, payload.Sign.Value)
+}
+
+func TestWebhook_ReauthorizesOn401(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	authorizeCalls := 0
+	authorizer := &countingAuthorizer{
+		onAuthorize: func() *Authorization {
+			authorizeCalls++
+			return &Authorization{Secret: "rotating-secret"}
+		},
+	}
+
+	wh := NewWebhook(server.URL, "local-secret", WithAuthorizer(authorizer))
+
+	payload := &Payload{
+		RunID:       "run-1",
+		Repo:        "acme/widgets",
+		GitRef:      "main",
+		GeneratedAt: time.Now(),
+	}
+
+	err := wh.Send(context.Background(), payload)
+	require.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+	assert.Equal(t, 2, authorizeCalls, "a 401 should trigger a fresh Authorize call")
+}
+
+// countingAuthorizer and its Invalidate method let TestWebhook_ReauthorizesOn401 assert Send
+// re-authorizes (rather than just retrying) after a 401, without standing up an HTTPAuthorizer
+// and a second authorize-endpoint handler.
+type countingAuthorizer struct {
+	onAuthorize func() *Authorization
+}
+
+func (c *countingAuthorizer) Authorize(ctx context.Context, repo, runID string) (*Authorization, error) {
+	return c.onAuthorize(), nil
+}
+
+func (c *countingAuthorizer) Invalidate(repo, runID string) {}
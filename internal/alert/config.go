@@ -0,0 +1,91 @@
+package alert
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// AlertsConfig is the `alerts:` block of the scanner configuration file. It's loaded
+// independently of scanner.ScannerConfig (rather than embedded in it) to avoid an import cycle,
+// since this package already imports internal/scanner for Payload.Findings.
+type AlertsConfig struct {
+	Transports []TransportConfig `yaml:"transports"`
+}
+
+// TransportConfig describes one configured Transport. Secret and routing-key material is never
+// stored inline: SecretRef names an environment variable that's resolved when the transport is
+// built.
+type TransportConfig struct {
+	Type      string `yaml:"type"` // webhook, generic-webhook, slack, teams, pagerduty, file, or stdout
+	Name      string `yaml:"name,omitempty"`
+	URL       string `yaml:"url,omitempty"`
+	Path      string `yaml:"path,omitempty"`
+	SecretRef string `yaml:"secret_ref,omitempty"`
+
+	// ObjectStore, when set, enables the findings-offload path (see Webhook.offloadFindingsIfNeeded)
+	// for webhook/generic-webhook transports. Ignored by every other type.
+	ObjectStore      *ObjectStoreConfig `yaml:"object_store,omitempty"`
+	OffloadThreshold int                `yaml:"offload_threshold,omitempty"`
+
+	// AuthorizerURL, when set, enables the pre-authorize handshake (see authorizer.go) for
+	// webhook/generic-webhook transports: Webhook.Send fetches per-destination policy from this
+	// control-plane server instead of relying solely on SecretRef. Ignored by every other type.
+	AuthorizerURL string `yaml:"authorizer_url,omitempty"`
+
+	// MaxInFlight, RateLimit, and Burst configure a Limiter (see limiter.go) for
+	// webhook/generic-webhook transports. MaxInFlight <= 0 leaves concurrency unbounded;
+	// RateLimit <= 0 leaves the send rate unbounded. Ignored by every other type.
+	MaxInFlight int     `yaml:"max_in_flight,omitempty"`
+	RateLimit   float64 `yaml:"rate_limit,omitempty"`
+	Burst       int     `yaml:"burst,omitempty"`
+}
+
+// LoadAlertsConfig reads the `alerts:` block out of the scanner configuration file at path. A
+// missing file yields a zero-value AlertsConfig (no transports configured), matching
+// scanner.LoadConfig's own not-found handling.
+func LoadAlertsConfig(path string) (*AlertsConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &AlertsConfig{}, nil
+		}
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	var wrapper struct {
+		Alerts AlertsConfig `yaml:"alerts"`
+	}
+	if err := yaml.Unmarshal(data, &wrapper); err != nil {
+		return nil, fmt.Errorf("failed to parse alerts config: %w", err)
+	}
+
+	return &wrapper.Alerts, nil
+}
+
+// BuildTransport looks up cfg.Type in the sink registry (see Register) and constructs the
+// Transport it describes, resolving cfg.SecretRef against the environment along the way.
+func BuildTransport(cfg TransportConfig) (Transport, error) {
+	factory, ok := sinkRegistry[cfg.Type]
+	if !ok {
+		return nil, fmt.Errorf("unknown alert transport type %q", cfg.Type)
+	}
+	return factory(cfg)
+}
+
+// BuildDispatcher constructs a Dispatcher from every transport in cfg. It fails the whole
+// configuration with the first entry that cannot be built, including one whose type is
+// unrecognized.
+func BuildDispatcher(cfg *AlertsConfig) (*Dispatcher, error) {
+	transports := make([]Transport, 0, len(cfg.Transports))
+	for _, tc := range cfg.Transports {
+		t, err := BuildTransport(tc)
+		if err != nil {
+			return nil, err
+		}
+		transports = append(transports, t)
+	}
+
+	return NewDispatcher(transports...), nil
+}
@@ -0,0 +1,91 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DispatchResult records the outcome of sending a Payload through one Transport.
+type DispatchResult struct {
+	Transport string
+	Err       error
+}
+
+// Dispatcher fans a single Payload out to multiple Transports, retrying each independently with
+// a linearly growing backoff, and stamps every payload with a nonce from a nonceStore shared across
+// the whole fan-out so replay-protection bookkeeping isn't reimplemented per transport.
+type Dispatcher struct {
+	transports []Transport
+	nonces     *nonceStore
+}
+
+// NewDispatcher creates a Dispatcher for the given transports.
+func NewDispatcher(transports ...Transport) *Dispatcher {
+	return &Dispatcher{
+		transports: transports,
+		nonces:     newNonceStore(maxAge),
+	}
+}
+
+// Dispatch sends payload to every configured transport concurrently and returns one
+// DispatchResult per transport, in the same order transports were registered. A single
+// transport failing does not stop delivery to the others.
+func (d *Dispatcher) Dispatch(ctx context.Context, payload *Payload) []DispatchResult {
+	nonce, err := d.nonces.generate()
+	if err == nil {
+		if d.nonces.isUsed(nonce) {
+			err = fmt.Errorf("replay attack detected")
+		} else {
+			d.nonces.store(nonce, payload.GeneratedAt)
+			payload.Nonce = nonce
+		}
+	}
+
+	results := make([]DispatchResult, len(d.transports))
+	done := make(chan struct{}, len(d.transports))
+
+	for i, t := range d.transports {
+		i, t := i, t
+		go func() {
+			defer func() { done <- struct{}{} }()
+
+			sendErr := err
+			if sendErr == nil {
+				sendErr = d.sendWithRetry(ctx, t, payload)
+			}
+			results[i] = DispatchResult{Transport: t.Name(), Err: sendErr}
+		}()
+	}
+
+	for range d.transports {
+		<-done
+	}
+
+	return results
+}
+
+// sendWithRetry calls t.Send up to maxRetries times in all, waiting (attempt+1)*baseDelay after
+// each failed attempt. It gives up early if ctx is canceled between attempts.
+func (d *Dispatcher) sendWithRetry(ctx context.Context, t Transport, payload *Payload) error {
+	var lastErr error
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := t.Send(ctx, payload); err != nil {
+			lastErr = err
+			select {
+			case <-time.After(time.Duration(attempt+1) * baseDelay):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			continue
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("transport %s failed after %d retries: %v", t.Name(), maxRetries, lastErr)
+}
@@ -0,0 +1,99 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubTransport records every Send call and fails the first failCount of them.
+type stubTransport struct {
+	name      string
+	failCount int32
+	attempts  int32
+	mu        sync.Mutex
+	payloads  []*Payload
+}
+
+func (s *stubTransport) Name() string { return s.name }
+
+func (s *stubTransport) Send(ctx context.Context, payload *Payload) error {
+	s.mu.Lock()
+	s.payloads = append(s.payloads, payload)
+	s.mu.Unlock()
+
+	if atomic.AddInt32(&s.attempts, 1) <= atomic.LoadInt32(&s.failCount) {
+		return errors.New("transient failure")
+	}
+	return nil
+}
+
+func TestDispatcher_SendsToAllTransports(t *testing.T) {
+	a := &stubTransport{name: "a"}
+	b := &stubTransport{name: "b"}
+	d := NewDispatcher(a, b)
+
+	results := d.Dispatch(context.Background(), &Payload{GeneratedAt: time.Now()})
+
+	require.Len(t, results, 2)
+	assert.Equal(t, "a", results[0].Transport)
+	assert.NoError(t, results[0].Err)
+	assert.Equal(t, "b", results[1].Transport)
+	assert.NoError(t, results[1].Err)
+}
+
+func TestDispatcher_OneTransportFailingDoesNotStopOthers(t *testing.T) {
+	failing := &stubTransport{name: "failing", failCount: int32(maxRetries)}
+	healthy := &stubTransport{name: "healthy"}
+	d := NewDispatcher(failing, healthy)
+
+	results := d.Dispatch(context.Background(), &Payload{GeneratedAt: time.Now()})
+
+	require.Len(t, results, 2)
+	assert.Error(t, results[0].Err)
+	assert.NoError(t, results[1].Err)
+}
+
+func TestDispatcher_RetriesTransientFailures(t *testing.T) {
+	flaky := &stubTransport{name: "flaky", failCount: 1}
+	d := NewDispatcher(flaky)
+
+	results := d.Dispatch(context.Background(), &Payload{GeneratedAt: time.Now()})
+
+	require.Len(t, results, 1)
+	assert.NoError(t, results[0].Err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&flaky.attempts))
+}
+
+func TestDispatcher_StampsSharedNonce(t *testing.T) {
+	a := &stubTransport{name: "a"}
+	b := &stubTransport{name: "b"}
+	d := NewDispatcher(a, b)
+
+	payload := &Payload{GeneratedAt: time.Now()}
+	d.Dispatch(context.Background(), payload)
+
+	require.NotEmpty(t, payload.Nonce)
+	require.Len(t, a.payloads, 1)
+	require.Len(t, b.payloads, 1)
+	assert.Equal(t, payload.Nonce, a.payloads[0].Nonce)
+	assert.Equal(t, payload.Nonce, b.payloads[0].Nonce)
+}
+
+func TestDispatcher_CanceledContextAbortsRetry(t *testing.T) {
+	alwaysFails := &stubTransport{name: "always", failCount: int32(maxRetries) + 10}
+	d := NewDispatcher(alwaysFails)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results := d.Dispatch(ctx, &Payload{GeneratedAt: time.Now()})
+	require.Len(t, results, 1)
+	assert.Error(t, results[0].Err)
+}
@@ -0,0 +1,44 @@
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// FileSink writes each Payload as a line of JSON to a local file, for air-gapped environments
+// with no outbound network access. Multiple sends append to the same file.
+type FileSink struct {
+	path string
+}
+
+// NewFileSink creates a FileSink that appends to the file at path, creating it if needed.
+func NewFileSink(path string) *FileSink {
+	return &FileSink{path: path}
+}
+
+// Name identifies this transport in Dispatcher results and logs.
+func (f *FileSink) Name() string {
+	return "file"
+}
+
+// Send appends payload, as a single line of JSON, to the sink's file.
+func (f *FileSink) Send(ctx context.Context, payload *Payload) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open alert sink file: %v", err)
+	}
+	defer file.Close()
+
+	enc := json.NewEncoder(file)
+	if err := enc.Encode(payload); err != nil {
+		return fmt.Errorf("failed to write alert to sink file: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package alert
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSink_SendAppendsJSONLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "alerts.jsonl")
+	sink := NewFileSink(path)
+	assert.Equal(t, "file", sink.Name())
+
+	require.NoError(t, sink.Send(context.Background(), &Payload{Summary: "first"}))
+	require.NoError(t, sink.Send(context.Background(), &Payload{Summary: "second"}))
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var summaries []string
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		var p Payload
+		require.NoError(t, json.Unmarshal(sc.Bytes(), &p))
+		summaries = append(summaries, p.Summary)
+	}
+	assert.Equal(t, []string{"first", "second"}, summaries)
+}
+
+func TestFileSink_Send_CanceledContext(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "alerts.jsonl")
+	sink := NewFileSink(path)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := sink.Send(ctx, &Payload{Summary: "dropped"})
+	assert.Error(t, err)
+	_, statErr := os.Stat(path)
+	assert.True(t, os.IsNotExist(statErr), "a canceled send should never create the sink file")
+}
+
+func TestFileSink_Send_UnwritablePath(t *testing.T) {
+	sink := NewFileSink(filepath.Join(t.TempDir(), "missing-dir", "alerts.jsonl"))
+	err := sink.Send(context.Background(), &Payload{Summary: "x"})
+	assert.Error(t, err)
+}
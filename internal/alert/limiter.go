@@ -0,0 +1,207 @@
+package alert
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Limiter bounds how many concurrent Sends a Webhook may have in flight to one destination, and
+// the rate at which it may start new ones, composed from a semaphore (MaxInFlight) and a token
+// bucket (Rate/Burst) - the same two controls a well-behaved client obeying both a connection
+// pool limit and a published rate limit would apply. Webhook.Send calls Wait before dialing and
+// the returned release func once the response is read, so a storm of queued retries (see Queue)
+// can't amplify into the very 429s that caused it.
+type Limiter struct {
+	maxInFlight int
+	sem         chan struct{} // nil means no concurrency bound
+
+	mu           sync.Mutex
+	rate         float64 // tokens per second; <= 0 means no rate bound
+	burst        float64
+	tokens       float64
+	lastRefill   time.Time
+	blockedUntil time.Time // set by AdjustFromResponse after a 429
+
+	inFlight    int64
+	waitSeconds float64
+}
+
+// NewLimiter creates a Limiter allowing at most maxInFlight concurrent Sends (0 = unlimited) and
+// a token bucket refilling at rate tokens/sec up to burst tokens (rate <= 0 = unlimited).
+func NewLimiter(maxInFlight int, rate float64, burst int) *Limiter {
+	l := &Limiter{
+		maxInFlight: maxInFlight,
+		rate:        rate,
+		lastRefill:  time.Now(),
+	}
+	if maxInFlight > 0 {
+		l.sem = make(chan struct{}, maxInFlight)
+	}
+	if burst <= 0 {
+		burst = 1
+	}
+	l.burst = float64(burst)
+	l.tokens = l.burst
+	return l
+}
+
+// Wait blocks until both the in-flight semaphore and the token bucket admit one more Send,
+// recording how long the call waited, and returns a release func the caller must invoke
+// (typically deferred) once that Send completes to free its in-flight slot. It returns ctx's
+// error if ctx is canceled while waiting.
+func (l *Limiter) Wait(ctx context.Context) (func(), error) {
+	start := time.Now()
+
+	if l.sem != nil {
+		select {
+		case l.sem <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if err := l.waitForToken(ctx); err != nil {
+		if l.sem != nil {
+			<-l.sem
+		}
+		return nil, err
+	}
+
+	l.mu.Lock()
+	l.inFlight++
+	l.waitSeconds += time.Since(start).Seconds()
+	l.mu.Unlock()
+
+	released := false
+	return func() {
+		if released {
+			return
+		}
+		released = true
+		l.mu.Lock()
+		l.inFlight--
+		l.mu.Unlock()
+		if l.sem != nil {
+			<-l.sem
+		}
+	}, nil
+}
+
+// waitForToken blocks until the token bucket has a token to spend (or ctx is canceled),
+// honoring any AdjustFromResponse-imposed blockedUntil deadline first.
+func (l *Limiter) waitForToken(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		now := time.Now()
+
+		if now.Before(l.blockedUntil) {
+			wait := l.blockedUntil.Sub(now)
+			l.mu.Unlock()
+			if err := sleepOrDone(ctx, wait); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if l.rate <= 0 {
+			l.mu.Unlock()
+			return nil
+		}
+
+		l.tokens += now.Sub(l.lastRefill).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+		l.lastRefill = now
+
+		if l.tokens >= 1 {
+			l.tokens--
+			l.mu.Unlock()
+			return nil
+		}
+
+		wait := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+		l.mu.Unlock()
+		if err := sleepOrDone(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// sleepOrDone sleeps for d, returning early with ctx's error if ctx is canceled first.
+func sleepOrDone(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// AdjustFromResponse reacts to a 429 by blocking the bucket until the destination's Retry-After
+// or X-RateLimit-Reset header says it's safe to try again, so a burst of queued retries backs
+// off to match what the destination actually asked for instead of hammering it at the
+// statically configured rate. It's a no-op for any other status or if neither header is present
+// or parseable.
+func (l *Limiter) AdjustFromResponse(resp *http.Response) {
+	if resp == nil || resp.StatusCode != http.StatusTooManyRequests {
+		return
+	}
+
+	delay, ok := retryDelayFromHeaders(resp.Header, time.Now())
+	if !ok || delay <= 0 {
+		return
+	}
+
+	until := time.Now().Add(delay)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if until.After(l.blockedUntil) {
+		l.blockedUntil = until
+	}
+}
+
+// retryDelayFromHeaders parses Retry-After (either a delay in seconds or an HTTP-date) and
+// falls back to X-RateLimit-Reset (a Unix timestamp, as GitHub's and many other APIs send it),
+// returning how long to wait from now.
+func retryDelayFromHeaders(h http.Header, now time.Time) (time.Duration, bool) {
+	if v := h.Get("Retry-After"); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil {
+			return time.Duration(secs) * time.Second, true
+		}
+		if when, err := http.ParseTime(v); err == nil {
+			return when.Sub(now), true
+		}
+	}
+
+	if v := h.Get("X-RateLimit-Reset"); v != "" {
+		if unix, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return time.Unix(unix, 0).Sub(now), true
+		}
+	}
+
+	return 0, false
+}
+
+// Metrics snapshots the counters a Limiter has recorded: how many Sends are currently in
+// flight, and the cumulative time every Send has spent waiting on Wait. Callers feed these into
+// whatever metrics registry they use (e.g. a Prometheus gauge for InFlight and a histogram sum
+// for WaitSeconds, matching alert_send_inflight / alert_send_wait_seconds) - this package has no
+// metrics client of its own.
+type Metrics struct {
+	InFlight    int
+	WaitSeconds float64
+}
+
+// Metrics returns a snapshot of l's current counters.
+func (l *Limiter) Metrics() Metrics {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return Metrics{InFlight: int(l.inFlight), WaitSeconds: l.waitSeconds}
+}
@@ -0,0 +1,158 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ejagojo/SentryScan/internal/scanner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhook_LimiterCapsConcurrency(t *testing.T) {
+	var (
+		mu          sync.Mutex
+		activeConns int
+		maxConns    int
+	)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		activeConns++
+		if activeConns > maxConns {
+			maxConns = activeConns
+		}
+		mu.Unlock()
+
+		time.Sleep(5 * time.Millisecond)
+
+		mu.Lock()
+		activeConns--
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	wh := NewWebhook(ts.URL, "test-secret", WithLimiter(NewLimiter(2, 0, 0)))
+
+	var wg sync.WaitGroup
+	numRequests := 100
+
+	for i := 0; i < numRequests; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			payload := &Payload{
+				RunID:       fmt.Sprintf("test-run-%d", i),
+				Findings:    []scanner.Finding{{RuleID: fmt.Sprintf("RULE-%d", i)}},
+				Repo:        "test/repo",
+				GitRef:      "main",
+				GeneratedAt: time.Now(),
+			}
+			assert.NoError(t, wh.Send(context.Background(), payload))
+		}(i)
+	}
+	wg.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Greater(t, maxConns, 0)
+	assert.LessOrEqual(t, maxConns, 2, "MaxInFlight=2 should cap observed concurrency")
+}
+
+func TestLimiter_TokenBucketSpreadsOutRequests(t *testing.T) {
+	l := NewLimiter(0, 10, 1) // 10 tokens/sec, burst of 1
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		release, err := l.Wait(context.Background())
+		require.NoError(t, err)
+		release()
+	}
+	elapsed := time.Since(start)
+
+	// 1 token is free; the other 2 each cost ~100ms at 10/sec.
+	assert.GreaterOrEqual(t, elapsed, 150*time.Millisecond)
+}
+
+func TestLimiter_WaitReturnsContextError(t *testing.T) {
+	l := NewLimiter(1, 0, 0)
+
+	release, err := l.Wait(context.Background())
+	require.NoError(t, err)
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = l.Wait(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestLimiter_AdjustFromResponseHonorsRetryAfter(t *testing.T) {
+	l := NewLimiter(0, 0, 0)
+
+	resp := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": []string{"1"}},
+	}
+	l.AdjustFromResponse(resp)
+
+	start := time.Now()
+	release, err := l.Wait(context.Background())
+	require.NoError(t, err)
+	release()
+
+	assert.GreaterOrEqual(t, time.Since(start), 900*time.Millisecond)
+}
+
+func TestLimiter_AdjustFromResponseIgnoresNon429(t *testing.T) {
+	l := NewLimiter(0, 0, 0)
+
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Retry-After": []string{"60"}},
+	}
+	l.AdjustFromResponse(resp)
+
+	start := time.Now()
+	release, err := l.Wait(context.Background())
+	require.NoError(t, err)
+	release()
+
+	assert.Less(t, time.Since(start), 50*time.Millisecond)
+}
+
+func TestLimiter_MetricsTracksInFlightAndWait(t *testing.T) {
+	l := NewLimiter(1, 0, 0)
+
+	release, err := l.Wait(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, l.Metrics().InFlight)
+
+	release()
+	assert.Equal(t, 0, l.Metrics().InFlight)
+}
+
+func TestRetryDelayFromHeaders(t *testing.T) {
+	now := time.Now()
+
+	d, ok := retryDelayFromHeaders(http.Header{"Retry-After": []string{"5"}}, now)
+	require.True(t, ok)
+	assert.Equal(t, 5*time.Second, d)
+
+	resetHeader := http.Header{}
+	resetHeader.Set("X-RateLimit-Reset", fmt.Sprintf("%d", now.Add(30*time.Second).Unix()))
+	d, ok = retryDelayFromHeaders(resetHeader, now)
+	require.True(t, ok)
+	assert.InDelta(t, 30*time.Second, d, float64(2*time.Second))
+
+	_, ok = retryDelayFromHeaders(http.Header{}, now)
+	assert.False(t, ok)
+}
@@ -0,0 +1,80 @@
+package alert
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const nonceSize = 32
+
+// nonceStore tracks recently-issued nonces so a replayed payload can be rejected. It's shared
+// by any transport that needs replay protection, so the bookkeeping lives in one place instead
+// of being reimplemented per transport.
+type nonceStore struct {
+	mu     sync.RWMutex
+	seen   map[string]time.Time
+	maxAge time.Duration
+}
+
+// newNonceStore creates a nonceStore that forgets a nonce maxAge after it was stored.
+func newNonceStore(maxAge time.Duration) *nonceStore {
+	return &nonceStore{
+		seen:   make(map[string]time.Time),
+		maxAge: maxAge,
+	}
+}
+
+// generate returns a fresh random nonce, unless testNonce has been set by a test.
+func (n *nonceStore) generate() (string, error) {
+	if testNonce != "" {
+		return testNonce, nil
+	}
+	b := make([]byte, nonceSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %v", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// isUsed reports whether nonce has already been stored and hasn't yet aged out.
+func (n *nonceStore) isUsed(nonce string) bool {
+	n.mu.RLock()
+	timestamp, exists := n.seen[nonce]
+	n.mu.RUnlock()
+
+	if !exists {
+		return false
+	}
+
+	if time.Since(timestamp) > n.maxAge {
+		n.mu.Lock()
+		delete(n.seen, nonce)
+		n.mu.Unlock()
+		return false
+	}
+
+	return true
+}
+
+// store records nonce as used as of timestamp.
+func (n *nonceStore) store(nonce string, timestamp time.Time) {
+	n.mu.Lock()
+	n.seen[nonce] = timestamp
+	n.mu.Unlock()
+}
+
+// cleanup removes every nonce older than maxAge.
+func (n *nonceStore) cleanup() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	now := time.Now()
+	for nonce, timestamp := range n.seen {
+		if now.Sub(timestamp) > n.maxAge {
+			delete(n.seen, nonce)
+		}
+	}
+}
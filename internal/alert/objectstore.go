@@ -0,0 +1,128 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// ObjectStore is what Webhook.Send needs to offload an oversized findings payload out of the
+// webhook body itself: upload it under key, tagged with contentEncoding so a receiver knows how
+// to stream-decode it, and return a URL it can be fetched back from.
+type ObjectStore interface {
+	Put(ctx context.Context, key string, data []byte, contentEncoding string) (url string, err error)
+}
+
+// ObjectStoreConfig configures NewObjectStore. Backend selects the upload transport: "file"
+// writes to a local directory - the default, dev-friendly backend with no network dependency -
+// while "s3", "gcs", "azure", and "http" are all served by the same presigned-URL PUT client,
+// since that's how GitLab Workhorse's own artifact-offload pattern talks to all three: none of
+// their SDKs are vendored here, so the cloud-specific names are accepted as aliases documenting
+// intent rather than selecting different wire protocols.
+type ObjectStoreConfig struct {
+	Backend string `yaml:"backend"`
+
+	// Dir is the local directory the "file" backend writes into.
+	Dir string `yaml:"dir,omitempty"`
+
+	// PutURLTemplate and GetURLTemplate back the presigned-URL backends ("s3", "gcs", "azure",
+	// "http"). Each must contain a "{key}" placeholder, substituted with the upload key (e.g.
+	// "https://bucket.s3.amazonaws.com/{key}?X-Amz-Signature=...").
+	PutURLTemplate string `yaml:"put_url_template,omitempty"`
+	GetURLTemplate string `yaml:"get_url_template,omitempty"`
+}
+
+// NewObjectStore constructs the ObjectStore cfg.Backend describes.
+func NewObjectStore(cfg ObjectStoreConfig) (ObjectStore, error) {
+	switch cfg.Backend {
+	case "", "file":
+		return &fileObjectStore{dir: cfg.Dir}, nil
+	case "s3", "gcs", "azure", "http":
+		if cfg.PutURLTemplate == "" || cfg.GetURLTemplate == "" {
+			return nil, fmt.Errorf("object store backend %q requires put_url_template and get_url_template", cfg.Backend)
+		}
+		return &presignedObjectStore{
+			putTemplate: cfg.PutURLTemplate,
+			getTemplate: cfg.GetURLTemplate,
+			client:      &http.Client{Timeout: 30 * time.Second},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown object store backend %q", cfg.Backend)
+	}
+}
+
+// fileObjectStore writes objects to a local directory, returning file:// URLs. It requires no
+// credentials or network access, making it a useful default for single-host CI runners and for
+// testing the offload path end to end.
+type fileObjectStore struct {
+	dir string
+}
+
+func (f *fileObjectStore) Put(ctx context.Context, key string, data []byte, contentEncoding string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(f.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to create object store directory: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write object: %v", err)
+	}
+
+	return "file://" + path, nil
+}
+
+// presignedObjectStore uploads via a plain HTTP PUT to a presigned URL derived from key,
+// mirroring how GitLab Workhorse hands artifacts off to S3/GCS/Azure without embedding any of
+// their SDKs.
+type presignedObjectStore struct {
+	putTemplate string
+	getTemplate string
+	client      *http.Client
+}
+
+func (p *presignedObjectStore) Put(ctx context.Context, key string, data []byte, contentEncoding string) (string, error) {
+	putURL := strings.ReplaceAll(p.putTemplate, "{key}", key)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, putURL, bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("failed to create object store request: %v", err)
+	}
+	if contentEncoding != "" {
+		req.Header.Set("Content-Encoding", contentEncoding)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload object: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("object store returned status %d", resp.StatusCode)
+	}
+
+	return strings.ReplaceAll(p.getTemplate, "{key}", key), nil
+}
+
+// zstdCompress compresses data as a single zstd frame, for the object-storage upload path
+// (Webhook.offloadFindingsIfNeeded) so oversized findings payloads are smaller in transit and
+// receivers can stream-decode them per the uploaded object's Content-Encoding.
+func zstdCompress(data []byte) ([]byte, error) {
+	enc, err := zstd.NewWriter(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create zstd encoder: %v", err)
+	}
+	defer enc.Close()
+
+	return enc.EncodeAll(data, nil), nil
+}
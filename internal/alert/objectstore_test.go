@@ -0,0 +1,93 @@
+package alert
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileObjectStore_PutRoundTrips(t *testing.T) {
+	dir := t.TempDir()
+	store := &fileObjectStore{dir: dir}
+
+	url, err := store.Put(context.Background(), "runs/run-1/abc.json.zst", []byte("compressed-bytes"), "zstd")
+	require.NoError(t, err)
+	assert.Equal(t, "file://"+filepath.Join(dir, "runs/run-1/abc.json.zst"), url)
+
+	data, err := os.ReadFile(filepath.Join(dir, "runs", "run-1", "abc.json.zst"))
+	require.NoError(t, err)
+	assert.Equal(t, "compressed-bytes", string(data))
+}
+
+func TestPresignedObjectStore_PutUsesTemplatesAndContentEncoding(t *testing.T) {
+	var gotEncoding string
+	var gotBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotEncoding = r.Header.Get("Content-Encoding")
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		gotBody = body
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	store := &presignedObjectStore{
+		putTemplate: server.URL + "/upload/{key}",
+		getTemplate: server.URL + "/download/{key}",
+		client:      server.Client(),
+	}
+
+	url, err := store.Put(context.Background(), "runs/run-1/abc.json.zst", []byte("hello"), "zstd")
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/download/runs/run-1/abc.json.zst", url)
+	assert.Equal(t, "zstd", gotEncoding)
+	assert.Equal(t, "hello", string(gotBody))
+}
+
+func TestPresignedObjectStore_PutErrorsOnNon2xx(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	store := &presignedObjectStore{
+		putTemplate: server.URL + "/upload/{key}",
+		getTemplate: server.URL + "/download/{key}",
+		client:      server.Client(),
+	}
+
+	_, err := store.Put(context.Background(), "k", []byte("x"), "")
+	assert.Error(t, err)
+}
+
+func TestNewObjectStore_UnknownBackend(t *testing.T) {
+	_, err := NewObjectStore(ObjectStoreConfig{Backend: "carrier-pigeon"})
+	assert.Error(t, err)
+}
+
+func TestNewObjectStore_PresignedRequiresTemplates(t *testing.T) {
+	_, err := NewObjectStore(ObjectStoreConfig{Backend: "s3"})
+	assert.Error(t, err)
+}
+
+func TestZstdCompress_RoundTripsThroughDecoder(t *testing.T) {
+	compressed, err := zstdCompress([]byte("hello world hello world hello world"))
+	require.NoError(t, err)
+	assert.NotEmpty(t, compressed)
+	assert.NotEqual(t, "hello world hello world hello world", string(compressed))
+
+	dec, err := zstd.NewReader(nil)
+	require.NoError(t, err)
+	defer dec.Close()
+	decoded, err := dec.DecodeAll(compressed, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "hello world hello world hello world", string(decoded))
+}
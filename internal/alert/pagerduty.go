@@ -0,0 +1,138 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ejagojo/SentryScan/internal/scanner"
+)
+
+const pagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// PagerDuty delivers a Payload as a PagerDuty Events API v2 "trigger" event.
+type PagerDuty struct {
+	routingKey string
+	client     *http.Client
+
+	// eventsURL defaults to pagerDutyEventsURL; overridable in tests so Send can be exercised
+	// against an httptest.Server instead of the real PagerDuty API.
+	eventsURL string
+}
+
+// NewPagerDuty creates a PagerDuty transport for the given Events API v2 integration key.
+func NewPagerDuty(routingKey string) *PagerDuty {
+	return &PagerDuty{
+		routingKey: routingKey,
+		client:     &http.Client{Timeout: 10 * time.Second},
+		eventsURL:  pagerDutyEventsURL,
+	}
+}
+
+// Name identifies this transport in Dispatcher results and logs.
+func (p *PagerDuty) Name() string {
+	return "pagerduty"
+}
+
+// Send triggers one PagerDuty event per distinct RuleID among payload.Findings, each deduped on
+// "<RunID>:<RuleID>" so a retried run updates the same incident per rule instead of opening a
+// duplicate, while two different rules from the same run still open separate incidents. A
+// payload with no findings triggers a single clean-run event deduped on RunID alone.
+func (p *PagerDuty) Send(ctx context.Context, payload *Payload) error {
+	groups := groupByRuleID(payload.Findings)
+	if len(groups) == 0 {
+		return p.sendEvent(ctx, payload.RunID, payload, nil)
+	}
+
+	var errs []string
+	for ruleID, findings := range groups {
+		dedupKey := fmt.Sprintf("%s:%s", payload.RunID, ruleID)
+		if err := p.sendEvent(ctx, dedupKey, payload, findings); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", ruleID, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("pagerduty: %d of %d rule events failed: %s", len(errs), len(groups), strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// sendEvent triggers a single PagerDuty Events API v2 event for findings (which may be a subset
+// of the full payload, grouped by rule), deduplicated on dedupKey.
+func (p *PagerDuty) sendEvent(ctx context.Context, dedupKey string, payload *Payload, findings []scanner.Finding) error {
+	event := map[string]interface{}{
+		"routing_key":  p.routingKey,
+		"event_action": "trigger",
+		"dedup_key":    dedupKey,
+		"payload": map[string]interface{}{
+			"summary":   payload.Summary,
+			"source":    payload.Repo,
+			"severity":  pagerDutySeverity(findings),
+			"timestamp": payload.GeneratedAt.Format(time.RFC3339),
+			"custom_details": map[string]interface{}{
+				"git_ref":       payload.GitRef,
+				"finding_count": len(findings),
+			},
+		},
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal pagerduty event: %v", err)
+	}
+
+	url := p.eventsURL
+	if url == "" {
+		url = pagerDutyEventsURL
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post to pagerduty: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("pagerduty returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// groupByRuleID buckets findings by RuleID, preserving each finding's order within its bucket.
+func groupByRuleID(findings []scanner.Finding) map[string][]scanner.Finding {
+	groups := make(map[string][]scanner.Finding)
+	for _, f := range findings {
+		groups[f.RuleID] = append(groups[f.RuleID], f)
+	}
+	return groups
+}
+
+// pagerDutySeverity maps our highest finding severity to one of PagerDuty's four fixed
+// severities, defaulting to "info" when there are no findings to report.
+func pagerDutySeverity(findings []scanner.Finding) string {
+	worst := "info"
+	for _, f := range findings {
+		switch f.Severity {
+		case "critical":
+			return "critical"
+		case "high":
+			worst = "error"
+		case "medium":
+			if worst != "error" {
+				worst = "warning"
+			}
+		}
+	}
+	return worst
+}
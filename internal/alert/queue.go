@@ -0,0 +1,433 @@
+package alert
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	mrand "math/rand"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+)
+
+const (
+	// queueBaseDelay and queueMaxDelay bound the full-jitter exponential backoff fullJitterBackoff
+	// computes between retry attempts.
+	queueBaseDelay = 500 * time.Millisecond
+	queueMaxDelay  = 5 * time.Minute
+
+	// queueMaxAge is how long a queued alert is retried before it's dead-lettered regardless of
+	// whether its last failure looked transient.
+	queueMaxAge = 24 * time.Hour
+
+	// queuePollInterval is how often Queue.Run checks the store for due entries when the caller
+	// doesn't override it.
+	queuePollInterval = 5 * time.Second
+)
+
+// QueueEntry is one durably-tracked outgoing Payload: queued for delivery through a named
+// Transport, carrying its attempt history so a crashed or restarted process picks up exactly
+// where it left off instead of losing the alert the way Dispatcher's in-process retry would.
+type QueueEntry struct {
+	ID             string    `json:"id"`
+	Transport      string    `json:"transport"`
+	Payload        Payload   `json:"payload"`
+	Attempt        int       `json:"attempt"`
+	NextAttemptAt  time.Time `json:"next_attempt_at"`
+	LastError      string    `json:"last_error,omitempty"`
+	EnqueuedAt     time.Time `json:"enqueued_at"`
+	DeadLetteredAt time.Time `json:"dead_lettered_at,omitempty"`
+}
+
+// QueueStore is the durable backing Queue drains: every queued Payload, its attempt count, and
+// its next-attempt time survive a process restart. JSONLQueueStore (this package's
+// dependency-free default) is the only implementation shipped here; a BoltDB- or SQLite-backed
+// store can satisfy the same interface without Queue itself changing.
+type QueueStore interface {
+	// Put upserts entry into the pending set.
+	Put(entry QueueEntry) error
+	// Remove drops entry.ID from the pending set once it's delivered.
+	Remove(id string) error
+	// All returns every entry currently in the pending set, in no particular order.
+	All() ([]QueueEntry, error)
+	// DeadLetter moves entry out of the pending set and into the dead-letter set.
+	DeadLetter(entry QueueEntry) error
+	// DeadLettered returns every dead-lettered entry whose DeadLetteredAt is at or after since.
+	DeadLettered(since time.Time) ([]QueueEntry, error)
+	// RemoveDeadLetter drops id from the dead-letter set, e.g. once ReplayDeadLetters has
+	// requeued it.
+	RemoveDeadLetter(id string) error
+}
+
+// Queue persists every Payload a Transport is asked to deliver and drains it in the background
+// with full-jitter exponential backoff, so a CI job exiting - or an endpoint being down for
+// minutes - doesn't lose the alert the way Dispatcher.sendWithRetry's in-process retry would.
+// Permanent failures (a 4xx the destination will never accept, 408/429 excepted since those mean
+// "try again") are moved straight to the store's dead-letter set; transient ones (5xx, network
+// errors, timeouts) stay on the retry schedule until queueMaxAge passes.
+type Queue struct {
+	store     QueueStore
+	transport Transport
+}
+
+// NewQueue creates a Queue that durably records Payloads in store and delivers them through
+// transport.
+func NewQueue(store QueueStore, transport Transport) *Queue {
+	return &Queue{store: store, transport: transport}
+}
+
+// Enqueue durably records payload for delivery through q.transport and returns the entry's ID.
+func (q *Queue) Enqueue(payload Payload) (string, error) {
+	id, err := randomQueueID()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	entry := QueueEntry{
+		ID:            id,
+		Transport:     q.transport.Name(),
+		Payload:       payload,
+		NextAttemptAt: now,
+		EnqueuedAt:    now,
+	}
+	if err := q.store.Put(entry); err != nil {
+		return "", fmt.Errorf("failed to enqueue alert: %v", err)
+	}
+
+	return id, nil
+}
+
+// Run drains due entries every pollInterval (queuePollInterval if pollInterval <= 0) until ctx is
+// canceled.
+func (q *Queue) Run(ctx context.Context, pollInterval time.Duration) error {
+	if pollInterval <= 0 {
+		pollInterval = queuePollInterval
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := q.Drain(ctx); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Drain attempts delivery of every pending entry whose NextAttemptAt has passed, returning how
+// many were delivered successfully. It does not wait for more entries to become due - call it
+// directly for a one-shot drain, or Run for a background worker loop.
+func (q *Queue) Drain(ctx context.Context) (int, error) {
+	entries, err := q.store.All()
+	if err != nil {
+		return 0, fmt.Errorf("failed to list queued alerts: %v", err)
+	}
+
+	now := time.Now()
+	delivered := 0
+	for _, entry := range entries {
+		if entry.NextAttemptAt.After(now) {
+			continue
+		}
+
+		payload := entry.Payload
+		if sendErr := q.transport.Send(ctx, &payload); sendErr != nil {
+			q.reschedule(entry, sendErr)
+			continue
+		}
+
+		if err := q.store.Remove(entry.ID); err != nil {
+			return delivered, fmt.Errorf("failed to remove delivered alert %s: %v", entry.ID, err)
+		}
+		delivered++
+	}
+
+	return delivered, nil
+}
+
+// reschedule records sendErr against entry and either dead-letters it (a permanent failure, or
+// one that's been retried past queueMaxAge) or bumps its attempt count and reschedules it with
+// full-jitter exponential backoff.
+func (q *Queue) reschedule(entry QueueEntry, sendErr error) {
+	entry.Attempt++
+	entry.LastError = sendErr.Error()
+
+	if isPermanentDeliveryError(sendErr) || time.Since(entry.EnqueuedAt) > queueMaxAge {
+		entry.DeadLetteredAt = time.Now()
+		_ = q.store.DeadLetter(entry)
+		return
+	}
+
+	entry.NextAttemptAt = time.Now().Add(fullJitterBackoff(entry.Attempt))
+	_ = q.store.Put(entry)
+}
+
+// ReplayDeadLetters moves every entry dead-lettered at or after cutoff back onto store's pending
+// set, resetting its attempt count and error, so Queue.Drain retries delivering it. It backs
+// `sentryscan alert replay --since=...` and returns how many entries were moved.
+func ReplayDeadLetters(store QueueStore, cutoff time.Time) (int, error) {
+	entries, err := store.DeadLettered(cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list dead-lettered alerts: %v", err)
+	}
+
+	for _, entry := range entries {
+		entry.Attempt = 0
+		entry.LastError = ""
+		entry.NextAttemptAt = time.Now()
+		entry.DeadLetteredAt = time.Time{}
+
+		if err := store.Put(entry); err != nil {
+			return 0, fmt.Errorf("failed to requeue alert %s: %v", entry.ID, err)
+		}
+		if err := store.RemoveDeadLetter(entry.ID); err != nil {
+			return 0, fmt.Errorf("failed to clear dead-lettered alert %s: %v", entry.ID, err)
+		}
+	}
+
+	return len(entries), nil
+}
+
+// fullJitterBackoff returns a random duration in [0, min(queueBaseDelay*2^attempt, queueMaxDelay)]
+// - the "full jitter" strategy from AWS's exponential backoff architecture blog post: spreading
+// retries across the whole window, rather than a fixed exponential delay, keeps every queued
+// alert from retrying a still-recovering destination in lockstep.
+func fullJitterBackoff(attempt int) time.Duration {
+	capped := float64(queueMaxDelay)
+	exp := float64(queueBaseDelay) * math.Pow(2, float64(attempt))
+	if exp > capped || math.IsInf(exp, 1) {
+		exp = capped
+	}
+	return time.Duration(mrand.Int63n(int64(exp) + 1))
+}
+
+// statusPattern extracts the HTTP status code from the error messages this package's own
+// transports format (e.g. "server returned status %d", "pagerduty returned status %d").
+var statusPattern = regexp.MustCompile(`status (\d+)`)
+
+// isPermanentDeliveryError reports whether err looks like a 4xx failure the destination will
+// never accept no matter how many times it's retried. 408 (Request Timeout) and 429 (Too Many
+// Requests) are excluded since both mean "try again", not "this request is invalid". Errors that
+// don't carry a recognizable status code - network errors, timeouts - are treated as transient,
+// for the same reason.
+func isPermanentDeliveryError(err error) bool {
+	m := statusPattern.FindStringSubmatch(err.Error())
+	if m == nil {
+		return false
+	}
+
+	var code int
+	if _, scanErr := fmt.Sscanf(m[1], "%d", &code); scanErr != nil {
+		return false
+	}
+
+	return code >= 400 && code < 500 && code != http.StatusRequestTimeout && code != http.StatusTooManyRequests
+}
+
+// randomQueueID returns a random 16-byte hex identifier for a new QueueEntry.
+func randomQueueID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate queue entry id: %v", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// queueRecord is one line of a JSONLQueueStore log: the entry as of that write, plus a tombstone
+// flag marking it removed. jsonlQueueStore.load replays every record in order, so the last one
+// logged for a given ID always wins.
+type queueRecord struct {
+	QueueEntry
+	Removed bool `json:"removed,omitempty"`
+}
+
+// jsonlQueueStore is QueueStore's dependency-free default: pending and dead-lettered entries
+// each live in their own append-only JSONL log under a directory, replayed into an in-memory
+// index on open the same way internal/cache's findings store is.
+type jsonlQueueStore struct {
+	mu sync.Mutex
+
+	pendingPath string
+	deadPath    string
+
+	pending    map[string]QueueEntry
+	deadLetter map[string]QueueEntry
+}
+
+// NewJSONLQueueStore creates (or reopens) a QueueStore backed by JSONL logs under dir, creating
+// dir if necessary.
+func NewJSONLQueueStore(dir string) (QueueStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create alert queue directory: %v", err)
+	}
+
+	s := &jsonlQueueStore{
+		pendingPath: filepath.Join(dir, "queue.jsonl"),
+		deadPath:    filepath.Join(dir, "dead-letter.jsonl"),
+		pending:     make(map[string]QueueEntry),
+		deadLetter:  make(map[string]QueueEntry),
+	}
+
+	if err := loadQueueLog(s.pendingPath, s.pending); err != nil {
+		return nil, err
+	}
+	if err := loadQueueLog(s.deadPath, s.deadLetter); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// loadQueueLog replays path's JSONL records into into, tolerating a missing file (a fresh
+// store) and a truncated trailing record (a crash mid-write).
+func loadQueueLog(path string, into map[string]QueueEntry) error {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to open alert queue log %s: %v", path, err)
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for sc.Scan() {
+		var rec queueRecord
+		if err := json.Unmarshal(sc.Bytes(), &rec); err != nil {
+			continue
+		}
+		if rec.Removed {
+			delete(into, rec.ID)
+			continue
+		}
+		into[rec.ID] = rec.QueueEntry
+	}
+
+	return nil
+}
+
+// appendQueueRecord appends rec, as one line of JSON, to the log at path.
+func appendQueueRecord(path string, rec queueRecord) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("failed to marshal alert queue record: %v", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to append to alert queue log %s: %v", path, err)
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+// Put implements QueueStore.
+func (s *jsonlQueueStore) Put(entry QueueEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := appendQueueRecord(s.pendingPath, queueRecord{QueueEntry: entry}); err != nil {
+		return err
+	}
+	s.pending[entry.ID] = entry
+	return nil
+}
+
+// Remove implements QueueStore.
+func (s *jsonlQueueStore) Remove(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := appendQueueRecord(s.pendingPath, queueRecord{QueueEntry: QueueEntry{ID: id}, Removed: true}); err != nil {
+		return err
+	}
+	delete(s.pending, id)
+	return nil
+}
+
+// All implements QueueStore.
+func (s *jsonlQueueStore) All() ([]QueueEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries := make([]QueueEntry, 0, len(s.pending))
+	for _, e := range s.pending {
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// DeadLetter implements QueueStore.
+func (s *jsonlQueueStore) DeadLetter(entry QueueEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := appendQueueRecord(s.pendingPath, queueRecord{QueueEntry: QueueEntry{ID: entry.ID}, Removed: true}); err != nil {
+		return err
+	}
+	delete(s.pending, entry.ID)
+
+	if err := appendQueueRecord(s.deadPath, queueRecord{QueueEntry: entry}); err != nil {
+		return err
+	}
+	s.deadLetter[entry.ID] = entry
+	return nil
+}
+
+// DeadLettered implements QueueStore.
+func (s *jsonlQueueStore) DeadLettered(since time.Time) ([]QueueEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var entries []QueueEntry
+	for _, e := range s.deadLetter {
+		if !e.DeadLetteredAt.Before(since) {
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
+
+// RemoveDeadLetter implements QueueStore.
+func (s *jsonlQueueStore) RemoveDeadLetter(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := appendQueueRecord(s.deadPath, queueRecord{QueueEntry: QueueEntry{ID: id}, Removed: true}); err != nil {
+		return err
+	}
+	delete(s.deadLetter, id)
+	return nil
+}
+
+// DefaultQueueDir returns $XDG_STATE_HOME/sentryscan/alert-queue, falling back to
+// ~/.local/state/sentryscan/alert-queue, the default directory NewJSONLQueueStore uses when no
+// path is explicitly configured.
+func DefaultQueueDir() string {
+	base := os.Getenv("XDG_STATE_HOME")
+	if base == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return filepath.Join(".local", "state", "sentryscan", "alert-queue")
+		}
+		base = filepath.Join(home, ".local", "state")
+	}
+	return filepath.Join(base, "sentryscan", "alert-queue")
+}
@@ -0,0 +1,207 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTransport lets queue tests script a sequence of Send outcomes without standing up an
+// httptest.Server.
+type fakeTransport struct {
+	mu    sync.Mutex
+	sends int
+	errs  []error // errs[i] is returned by the (i+1)th Send call; the last entry repeats
+}
+
+func (f *fakeTransport) Name() string { return "fake" }
+
+func (f *fakeTransport) Send(ctx context.Context, payload *Payload) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	i := f.sends
+	if i >= len(f.errs) {
+		i = len(f.errs) - 1
+	}
+	f.sends++
+	return f.errs[i]
+}
+
+func TestJSONLQueueStore_PutAndRemoveRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewJSONLQueueStore(dir)
+	require.NoError(t, err)
+
+	entry := QueueEntry{ID: "a", Transport: "fake", EnqueuedAt: time.Now()}
+	require.NoError(t, store.Put(entry))
+
+	all, err := store.All()
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	assert.Equal(t, "a", all[0].ID)
+
+	require.NoError(t, store.Remove("a"))
+	all, err = store.All()
+	require.NoError(t, err)
+	assert.Empty(t, all)
+}
+
+func TestJSONLQueueStore_SurvivesReopen(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewJSONLQueueStore(dir)
+	require.NoError(t, err)
+	require.NoError(t, store.Put(QueueEntry{ID: "a", EnqueuedAt: time.Now()}))
+	require.NoError(t, store.DeadLetter(QueueEntry{ID: "b", EnqueuedAt: time.Now(), DeadLetteredAt: time.Now()}))
+
+	reopened, err := NewJSONLQueueStore(dir)
+	require.NoError(t, err)
+
+	pending, err := reopened.All()
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "a", pending[0].ID)
+
+	dead, err := reopened.DeadLettered(time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	assert.Equal(t, "b", dead[0].ID)
+}
+
+func TestQueue_DrainDeliversAndRemoves(t *testing.T) {
+	store, err := NewJSONLQueueStore(t.TempDir())
+	require.NoError(t, err)
+	transport := &fakeTransport{errs: []error{nil}}
+	q := NewQueue(store, transport)
+
+	_, err = q.Enqueue(Payload{RunID: "run-1"})
+	require.NoError(t, err)
+
+	delivered, err := q.Drain(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+
+	pending, err := store.All()
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+func TestQueue_DrainReschedulesTransientFailure(t *testing.T) {
+	store, err := NewJSONLQueueStore(t.TempDir())
+	require.NoError(t, err)
+	transport := &fakeTransport{errs: []error{fmt.Errorf("server returned status 503")}}
+	q := NewQueue(store, transport)
+
+	_, err = q.Enqueue(Payload{RunID: "run-1"})
+	require.NoError(t, err)
+
+	delivered, err := q.Drain(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, delivered)
+
+	pending, err := store.All()
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, 1, pending[0].Attempt)
+	assert.Contains(t, pending[0].LastError, "503")
+
+	dead, err := store.DeadLettered(time.Time{})
+	require.NoError(t, err)
+	assert.Empty(t, dead)
+}
+
+func TestQueue_DrainDeadLettersPermanentFailure(t *testing.T) {
+	store, err := NewJSONLQueueStore(t.TempDir())
+	require.NoError(t, err)
+	transport := &fakeTransport{errs: []error{fmt.Errorf("server returned status 422")}}
+	q := NewQueue(store, transport)
+
+	_, err = q.Enqueue(Payload{RunID: "run-1"})
+	require.NoError(t, err)
+
+	delivered, err := q.Drain(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, delivered)
+
+	pending, err := store.All()
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	dead, err := store.DeadLettered(time.Time{})
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	assert.Contains(t, dead[0].LastError, "422")
+}
+
+func TestIsPermanentDeliveryError(t *testing.T) {
+	cases := []struct {
+		err       error
+		permanent bool
+	}{
+		{fmt.Errorf("server returned status 400"), true},
+		{fmt.Errorf("server returned status 404"), true},
+		{fmt.Errorf("server returned status 408"), false},
+		{fmt.Errorf("server returned status 429"), false},
+		{fmt.Errorf("server returned status 500"), false},
+		{fmt.Errorf("server returned status 503"), false},
+		{fmt.Errorf("dial tcp: connection refused"), false},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.permanent, isPermanentDeliveryError(c.err), c.err.Error())
+	}
+}
+
+func TestReplayDeadLetters_MovesEntriesBackToPending(t *testing.T) {
+	store, err := NewJSONLQueueStore(t.TempDir())
+	require.NoError(t, err)
+
+	require.NoError(t, store.DeadLetter(QueueEntry{
+		ID:             "a",
+		EnqueuedAt:     time.Now().Add(-time.Hour),
+		DeadLetteredAt: time.Now().Add(-time.Minute),
+		Attempt:        3,
+		LastError:      "server returned status 500",
+	}))
+
+	replayed, err := ReplayDeadLetters(store, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, replayed)
+
+	pending, err := store.All()
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, 0, pending[0].Attempt)
+	assert.Empty(t, pending[0].LastError)
+
+	dead, err := store.DeadLettered(time.Time{})
+	require.NoError(t, err)
+	assert.Empty(t, dead)
+}
+
+func TestReplayDeadLetters_IgnoresEntriesBeforeCutoff(t *testing.T) {
+	store, err := NewJSONLQueueStore(t.TempDir())
+	require.NoError(t, err)
+
+	require.NoError(t, store.DeadLetter(QueueEntry{
+		ID:             "old",
+		EnqueuedAt:     time.Now().Add(-48 * time.Hour),
+		DeadLetteredAt: time.Now().Add(-47 * time.Hour),
+	}))
+
+	replayed, err := ReplayDeadLetters(store, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 0, replayed)
+}
+
+func TestFullJitterBackoff_NeverExceedsCap(t *testing.T) {
+	for attempt := 0; attempt < 20; attempt++ {
+		d := fullJitterBackoff(attempt)
+		assert.GreaterOrEqual(t, d, time.Duration(0))
+		assert.LessOrEqual(t, d, queueMaxDelay)
+	}
+}
@@ -0,0 +1,65 @@
+package alert
+
+import "os"
+
+// SinkFactory builds a Transport from its TransportConfig. Adapters register a factory under a
+// scheme name (see Register) so BuildTransport can resolve any known sink type from config
+// without a hardcoded switch - the same registered-adapter-chosen-by-name pattern Git LFS uses
+// to pick a batch-API transfer adapter by name.
+type SinkFactory func(cfg TransportConfig) (Transport, error)
+
+var sinkRegistry = make(map[string]SinkFactory)
+
+// Register adds a named sink factory to the registry, so a later BuildTransport(cfg) with
+// cfg.Type == name constructs it. Built-in adapters register themselves in this file's init();
+// callers outside this package can register their own to extend BuildTransport without editing
+// it.
+func Register(name string, factory SinkFactory) {
+	sinkRegistry[name] = factory
+}
+
+func init() {
+	Register("webhook", newWebhookFromConfig)
+	Register("generic-webhook", newWebhookFromConfig)
+	Register("slack", func(cfg TransportConfig) (Transport, error) {
+		return NewSlack(cfg.URL), nil
+	})
+	Register("teams", func(cfg TransportConfig) (Transport, error) {
+		return NewTeams(cfg.URL), nil
+	})
+	Register("pagerduty", func(cfg TransportConfig) (Transport, error) {
+		return NewPagerDuty(os.Getenv(cfg.SecretRef)), nil
+	})
+	Register("file", func(cfg TransportConfig) (Transport, error) {
+		return NewFileSink(cfg.Path), nil
+	})
+	Register("stdout", func(cfg TransportConfig) (Transport, error) {
+		return NewStdout(), nil
+	})
+}
+
+// newWebhookFromConfig builds a Webhook, wiring an ObjectStore (and offload threshold override)
+// onto it when cfg.ObjectStore is set, an HTTPAuthorizer when cfg.AuthorizerURL is set, and a
+// Limiter when cfg.MaxInFlight or cfg.RateLimit is set.
+func newWebhookFromConfig(cfg TransportConfig) (Transport, error) {
+	var opts []WebhookOption
+
+	if cfg.ObjectStore != nil {
+		store, err := NewObjectStore(*cfg.ObjectStore)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, WithObjectStore(store))
+	}
+	if cfg.OffloadThreshold > 0 {
+		opts = append(opts, WithOffloadThreshold(cfg.OffloadThreshold))
+	}
+	if cfg.AuthorizerURL != "" {
+		opts = append(opts, WithAuthorizer(NewHTTPAuthorizer(cfg.AuthorizerURL)))
+	}
+	if cfg.MaxInFlight > 0 || cfg.RateLimit > 0 {
+		opts = append(opts, WithLimiter(NewLimiter(cfg.MaxInFlight, cfg.RateLimit, cfg.Burst)))
+	}
+
+	return NewWebhook(cfg.URL, os.Getenv(cfg.SecretRef), opts...), nil
+}
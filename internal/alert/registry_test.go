@@ -0,0 +1,105 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ejagojo/SentryScan/internal/scanner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildTransport_KnownTypes(t *testing.T) {
+	for _, typ := range []string{"webhook", "generic-webhook", "slack", "teams", "pagerduty", "file", "stdout"} {
+		transport, err := BuildTransport(TransportConfig{Type: typ, URL: "https://example.test/hook", Path: "/tmp/sentryscan-alerts.log"})
+		require.NoError(t, err, "type %q", typ)
+		assert.NotNil(t, transport)
+	}
+}
+
+func TestBuildTransport_UnknownType(t *testing.T) {
+	_, err := BuildTransport(TransportConfig{Type: "carrier-pigeon"})
+	assert.Error(t, err)
+}
+
+func TestRegister_AddsCustomSink(t *testing.T) {
+	called := false
+	Register("test-custom-sink", func(cfg TransportConfig) (Transport, error) {
+		called = true
+		return NewStdout(), nil
+	})
+
+	transport, err := BuildTransport(TransportConfig{Type: "test-custom-sink"})
+	require.NoError(t, err)
+	assert.NotNil(t, transport)
+	assert.True(t, called)
+}
+
+func TestTeamsSend_ThemesByWorstSeverity(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	teams := NewTeams(server.URL)
+	payload := &Payload{
+		RunID:   "run-1",
+		Summary: "2 findings",
+		Repo:    "acme/widgets",
+		GitRef:  "main",
+		Findings: []scanner.Finding{
+			{RuleID: "aws-access-key", Severity: "high", Path: "a.env", Line: 1},
+			{RuleID: "generic-token", Severity: "critical", Path: "b.env", Line: 2},
+		},
+		GeneratedAt: time.Now(),
+	}
+
+	require.NoError(t, teams.Send(context.Background(), payload))
+	assert.Equal(t, severityColor("critical"), body["themeColor"])
+	assert.Equal(t, "MessageCard", body["@type"])
+}
+
+func TestStdoutSend_WritesJSONLine(t *testing.T) {
+	var buf bytes.Buffer
+	s := &Stdout{w: &buf}
+
+	payload := &Payload{RunID: "run-1", Summary: "clean run", GeneratedAt: time.Now()}
+	require.NoError(t, s.Send(context.Background(), payload))
+
+	var decoded Payload
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, "run-1", decoded.RunID)
+}
+
+func TestPagerDutySend_DedupKeyPerRule(t *testing.T) {
+	var dedupKeys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+		dedupKeys = append(dedupKeys, event["dedup_key"].(string))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	pd := &PagerDuty{routingKey: "test-key", client: server.Client(), eventsURL: server.URL}
+
+	payload := &Payload{
+		RunID: "run-42",
+		Findings: []scanner.Finding{
+			{RuleID: "aws-access-key", Severity: "high"},
+			{RuleID: "generic-token", Severity: "medium"},
+		},
+		GeneratedAt: time.Now(),
+	}
+
+	err := pd.Send(context.Background(), payload)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"run-42:aws-access-key", "run-42:generic-token"}, dedupKeys)
+}
@@ -0,0 +1,42 @@
+package alert
+
+import "github.com/ejagojo/SentryScan/internal/scanner"
+
+// severityRank orders severities from least to most urgent, used to pick the single "worst"
+// severity across a batch of findings for transports (Slack, Teams) that color-code a whole
+// message rather than each finding individually.
+var severityRank = map[string]int{
+	"info":     0,
+	"low":      1,
+	"medium":   2,
+	"high":     3,
+	"critical": 4,
+}
+
+// worstSeverity returns the highest-ranked severity among findings, or "info" if there are none.
+func worstSeverity(findings []scanner.Finding) string {
+	worst := "info"
+	for _, f := range findings {
+		if severityRank[f.Severity] > severityRank[worst] {
+			worst = f.Severity
+		}
+	}
+	return worst
+}
+
+// severityColor maps a severity to the hex color Slack attachments and Teams MessageCards use
+// to draw their colored sidebar/theme.
+func severityColor(severity string) string {
+	switch severity {
+	case "critical":
+		return "#9B0000"
+	case "high":
+		return "#E01E5A"
+	case "medium":
+		return "#ECB22E"
+	case "low":
+		return "#2EB67D"
+	default:
+		return "#36C5F0"
+	}
+}
@@ -0,0 +1,115 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Slack delivers a Payload to a Slack incoming webhook as a Block Kit message.
+type Slack struct {
+	webhookURL string
+	client     *http.Client
+}
+
+// NewSlack creates a Slack transport that posts to the given incoming webhook URL.
+func NewSlack(webhookURL string) *Slack {
+	return &Slack{
+		webhookURL: webhookURL,
+		client:     &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Name identifies this transport in Dispatcher results and logs.
+func (s *Slack) Name() string {
+	return "slack"
+}
+
+// Send posts payload to the configured Slack incoming webhook as Block Kit blocks, wrapped in a
+// single attachment colored by the worst severity among payload.Findings so the message's
+// sidebar reads at a glance without opening it.
+func (s *Slack) Send(ctx context.Context, payload *Payload) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"attachments": []map[string]interface{}{
+			{
+				"color":  severityColor(worstSeverity(payload.Findings)),
+				"blocks": buildSlackBlocks(payload),
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal slack payload: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", s.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post to slack: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("slack returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// buildSlackBlocks renders payload as Block Kit: a header, a summary section, and one section
+// per finding (Slack caps blocks at 50 per message, so findings beyond that are summarized).
+func buildSlackBlocks(payload *Payload) []map[string]interface{} {
+	const maxFindingBlocks = 45
+
+	blocks := []map[string]interface{}{
+		{
+			"type": "header",
+			"text": map[string]interface{}{
+				"type": "plain_text",
+				"text": fmt.Sprintf("SentryScan: %s", payload.Summary),
+			},
+		},
+		{
+			"type": "section",
+			"text": map[string]interface{}{
+				"type": "mrkdwn",
+				"text": fmt.Sprintf("*Repo:* %s\n*Ref:* %s\n*Run:* %s", payload.Repo, payload.GitRef, payload.RunID),
+			},
+		},
+	}
+
+	shown := payload.Findings
+	truncated := 0
+	if len(shown) > maxFindingBlocks {
+		truncated = len(shown) - maxFindingBlocks
+		shown = shown[:maxFindingBlocks]
+	}
+
+	for _, f := range shown {
+		blocks = append(blocks, map[string]interface{}{
+			"type": "section",
+			"text": map[string]interface{}{
+				"type": "mrkdwn",
+				"text": fmt.Sprintf("*%s* (%s) — `%s:%d`\n%s", f.RuleID, f.Severity, f.Path, f.Line, f.Description),
+			},
+		})
+	}
+
+	if truncated > 0 {
+		blocks = append(blocks, map[string]interface{}{
+			"type": "context",
+			"elements": []map[string]interface{}{
+				{"type": "mrkdwn", "text": fmt.Sprintf("_%d additional findings omitted_", truncated)},
+			},
+		})
+	}
+
+	return blocks
+}
@@ -0,0 +1,69 @@
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ejagojo/SentryScan/internal/scanner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlackSend_PostsBlockKitMessage(t *testing.T) {
+	var gotBody map[string]interface{}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&gotBody))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	s := NewSlack(ts.URL)
+	assert.Equal(t, "slack", s.Name())
+
+	payload := &Payload{
+		Summary: "2 findings",
+		Repo:    "org/repo",
+		GitRef:  "refs/heads/main",
+		RunID:   "run-1",
+		Findings: []scanner.Finding{
+			{RuleID: "aws-access-key", Severity: "critical", Path: "a.txt", Line: 3, Description: "AWS key"},
+		},
+	}
+
+	err := s.Send(context.Background(), payload)
+	require.NoError(t, err)
+
+	attachments, ok := gotBody["attachments"].([]interface{})
+	require.True(t, ok)
+	require.Len(t, attachments, 1)
+	attachment := attachments[0].(map[string]interface{})
+	assert.Equal(t, severityColor("critical"), attachment["color"])
+}
+
+func TestSlackSend_NonSuccessStatusIsError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	s := NewSlack(ts.URL)
+	err := s.Send(context.Background(), &Payload{})
+	assert.Error(t, err)
+}
+
+func TestBuildSlackBlocks_TruncatesBeyondLimit(t *testing.T) {
+	findings := make([]scanner.Finding, 50)
+	for i := range findings {
+		findings[i] = scanner.Finding{RuleID: "rule", Severity: "low", Path: "f.txt", Line: i}
+	}
+
+	blocks := buildSlackBlocks(&Payload{Summary: "many findings", Findings: findings})
+
+	last := blocks[len(blocks)-1]
+	assert.Equal(t, "context", last["type"])
+}
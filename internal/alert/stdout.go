@@ -0,0 +1,39 @@
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Stdout writes each Payload as a single line of JSON to an io.Writer (os.Stdout by default),
+// for local runs and CI logs where piping to a file or webhook is unnecessary ceremony.
+type Stdout struct {
+	w io.Writer
+}
+
+// NewStdout creates a Stdout transport that writes to os.Stdout.
+func NewStdout() *Stdout {
+	return &Stdout{w: os.Stdout}
+}
+
+// Name identifies this transport in Dispatcher results and logs.
+func (s *Stdout) Name() string {
+	return "stdout"
+}
+
+// Send writes payload, as a single line of JSON, to s.w.
+func (s *Stdout) Send(ctx context.Context, payload *Payload) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(s.w)
+	if err := enc.Encode(payload); err != nil {
+		return fmt.Errorf("failed to write alert to stdout: %v", err)
+	}
+
+	return nil
+}
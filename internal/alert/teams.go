@@ -0,0 +1,98 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Teams delivers a Payload to a Microsoft Teams incoming webhook as an O365 connector
+// MessageCard.
+type Teams struct {
+	webhookURL string
+	client     *http.Client
+}
+
+// NewTeams creates a Teams transport that posts to the given incoming webhook URL.
+func NewTeams(webhookURL string) *Teams {
+	return &Teams{
+		webhookURL: webhookURL,
+		client:     &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Name identifies this transport in Dispatcher results and logs.
+func (t *Teams) Name() string {
+	return "teams"
+}
+
+// Send posts payload to the configured Teams incoming webhook as a MessageCard, themed by the
+// worst severity among payload.Findings.
+func (t *Teams) Send(ctx context.Context, payload *Payload) error {
+	body, err := json.Marshal(buildTeamsCard(payload))
+	if err != nil {
+		return fmt.Errorf("failed to marshal teams payload: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", t.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post to teams: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("teams returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// buildTeamsCard renders payload as an O365 connector MessageCard: a themed title, a facts
+// section summarizing the run, and one text line per finding (Teams cards get unwieldy past a
+// few dozen facts, so findings beyond maxFindingFacts are summarized instead).
+func buildTeamsCard(payload *Payload) map[string]interface{} {
+	const maxFindingFacts = 45
+
+	shown := payload.Findings
+	truncated := 0
+	if len(shown) > maxFindingFacts {
+		truncated = len(shown) - maxFindingFacts
+		shown = shown[:maxFindingFacts]
+	}
+
+	var lines []string
+	for _, f := range shown {
+		lines = append(lines, fmt.Sprintf("**%s** (%s) — `%s:%d` — %s", f.RuleID, f.Severity, f.Path, f.Line, f.Description))
+	}
+	if truncated > 0 {
+		lines = append(lines, fmt.Sprintf("_%d additional findings omitted_", truncated))
+	}
+
+	return map[string]interface{}{
+		"@type":      "MessageCard",
+		"@context":   "http://schema.org/extensions",
+		"summary":    payload.Summary,
+		"themeColor": severityColor(worstSeverity(payload.Findings)),
+		"title":      fmt.Sprintf("SentryScan: %s", payload.Summary),
+		"sections": []map[string]interface{}{
+			{
+				"facts": []map[string]interface{}{
+					{"name": "Repo", "value": payload.Repo},
+					{"name": "Ref", "value": payload.GitRef},
+					{"name": "Run", "value": payload.RunID},
+				},
+				"text": strings.Join(lines, "\n\n"),
+			},
+		},
+	}
+}
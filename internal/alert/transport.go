@@ -0,0 +1,12 @@
+package alert
+
+import "context"
+
+// Transport delivers a Payload to a single destination (a webhook receiver, Slack, PagerDuty,
+// a local file, etc). Implementations own their own formatting and authentication; retrying a
+// failed send and stamping each fan-out with a replay nonce no earlier Dispatch has used is the
+// Dispatcher's job, not the transport's.
+type Transport interface {
+	Send(ctx context.Context, payload *Payload) error
+	Name() string
+}
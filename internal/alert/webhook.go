@@ -2,15 +2,15 @@ package alert
 
 import (
 	"bytes"
+	"context"
 	"crypto/hmac"
-	"crypto/rand"
 	"crypto/sha256"
+	"crypto/sha512"
 	"encoding/base64"
-	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"hash"
 	"net/http"
-	"sync"
 	"time"
 
 	"github.com/ejagojo/SentryScan/internal/scanner"
@@ -20,7 +20,13 @@ const (
 	maxRetries = 3
 	baseDelay  = 500 * time.Millisecond
 	maxAge     = 10 * time.Minute
-	nonceSize  = 32
+
+	// defaultOffloadThreshold is how large payload.Findings' JSON encoding must get before
+	// Webhook.Send offloads it to an ObjectStore instead of inlining it in the webhook body.
+	defaultOffloadThreshold = 256 * 1024
+
+	// offloadTTL is how long the presigned GET URL in a FindingsRef is considered valid.
+	offloadTTL = 24 * time.Hour
 )
 
 // For testing purposes
@@ -31,23 +37,80 @@ var (
 
 // Webhook represents a webhook alert configuration
 type Webhook struct {
-	url      string
-	secret   []byte
-	client   *http.Client
-	nonces   map[string]time.Time
-	nonceMux sync.RWMutex
+	url    string
+	secret []byte
+	client *http.Client
+	nonces *nonceStore
+
+	// objectStore and offloadThreshold implement the findings-offload path (see
+	// offloadFindingsIfNeeded): payload.Findings larger than offloadThreshold are uploaded to
+	// objectStore and replaced with a FindingsRef instead of being inlined in the webhook body.
+	// objectStore is nil (offload disabled) unless WithObjectStore is passed to NewWebhook.
+	objectStore      ObjectStore
+	offloadThreshold int
+
+	// authorizer implements the pre-authorize handshake (see authorizer.go): when set, Send asks
+	// it for per-destination policy before every request instead of relying solely on the
+	// locally configured secret. It defaults to a StaticAuthorizer(nil), i.e. "no policy",
+	// unless WithAuthorizer is passed to NewWebhook.
+	authorizer Authorizer
+
+	// limiter bounds concurrency and rate for every attempt Send makes against this destination
+	// (see limiter.go). It's nil (unbounded, matching the pre-existing behavior) unless
+	// WithLimiter is passed to NewWebhook.
+	limiter *Limiter
+}
+
+// WebhookOption configures optional Webhook behavior not needed by every caller, following the
+// functional-options pattern so NewWebhook's required (url, secret) signature doesn't grow a
+// new positional parameter per feature.
+type WebhookOption func(*Webhook)
+
+// WithObjectStore enables the findings-offload path: payload.Findings larger than the
+// configured (or default) offload threshold are uploaded to store instead of inlined.
+func WithObjectStore(store ObjectStore) WebhookOption {
+	return func(w *Webhook) { w.objectStore = store }
+}
+
+// WithOffloadThreshold overrides defaultOffloadThreshold, the JSON-encoded size of
+// payload.Findings above which Webhook.Send offloads them to the configured ObjectStore.
+func WithOffloadThreshold(bytes int) WebhookOption {
+	return func(w *Webhook) { w.offloadThreshold = bytes }
+}
+
+// WithAuthorizer enables the pre-authorize handshake: before every Send, authorizer is asked for
+// this destination's policy (severity floor, payload limits, signing secret, rate limits),
+// cached until it expires. Pass an *HTTPAuthorizer to delegate policy to a control-plane server,
+// or a *StaticAuthorizer to pin fixed values without a network call.
+func WithAuthorizer(authorizer Authorizer) WebhookOption {
+	return func(w *Webhook) { w.authorizer = authorizer }
+}
+
+// WithLimiter bounds how many requests Send may have in flight to this destination at once, and
+// the rate at which it may start new ones (see limiter.go). Without this option Send is
+// unbounded, matching the pre-existing behavior.
+func WithLimiter(limiter *Limiter) WebhookOption {
+	return func(w *Webhook) { w.limiter = limiter }
 }
 
 // NewWebhook creates a new webhook alert instance
-func NewWebhook(url string, secret string) *Webhook {
-	return &Webhook{
+func NewWebhook(url string, secret string, opts ...WebhookOption) *Webhook {
+	w := &Webhook{
 		url:    url,
 		secret: []byte(secret),
 		client: &http.Client{
 			Timeout: 10 * time.Second,
 		},
-		nonces: make(map[string]time.Time),
+		nonces:           newNonceStore(maxAge),
+		offloadThreshold: defaultOffloadThreshold,
+		authorizer:       NewStaticAuthorizer(nil),
+	}
+
+	for _, opt := range opts {
+		opt(w)
 	}
+
+	return w
 }
 
 // Payload represents the webhook payload
@@ -60,6 +123,22 @@ type Payload struct {
 	GeneratedAt time.Time         `json:"generated_at"`
 	Nonce       string            `json:"nonce"`
 	Sign        *Signature        `json:"signature,omitempty"`
+
+	// FindingsRef and FindingsCount are set by Webhook.Send in place of Findings when the
+	// findings JSON is too large to inline (see offloadFindingsIfNeeded): the receiver fetches
+	// the full findings from FindingsRef.URL and can still show a count without doing so.
+	FindingsRef   *FindingsRef `json:"findings_ref,omitempty"`
+	FindingsCount int          `json:"findings_count,omitempty"`
+}
+
+// FindingsRef points at a findings payload too large to inline in the webhook body, uploaded to
+// object storage by Webhook.Send when an ObjectStore is configured (see WithObjectStore) and
+// the JSON-encoded findings exceed the offload threshold.
+type FindingsRef struct {
+	URL       string    `json:"url"`
+	SHA256    string    `json:"sha256"`
+	Bytes     int64     `json:"bytes"`
+	ExpiresAt time.Time `json:"expires_at"`
 }
 
 // Signature represents the HMAC signature
@@ -70,64 +149,149 @@ type Signature struct {
 
 // generateNonce creates a new random nonce
 func (w *Webhook) generateNonce() (string, error) {
-	if testNonce != "" {
-		return testNonce, nil
-	}
-	nonceBytes := make([]byte, nonceSize)
-	if _, err := rand.Read(nonceBytes); err != nil {
-		return "", fmt.Errorf("failed to generate nonce: %v", err)
-	}
-	return hex.EncodeToString(nonceBytes), nil
+	return w.nonces.generate()
 }
 
 // isNonceUsed checks if a nonce has been used and not expired
 func (w *Webhook) isNonceUsed(nonce string) bool {
-	w.nonceMux.RLock()
-	timestamp, exists := w.nonces[nonce]
-	w.nonceMux.RUnlock()
+	return w.nonces.isUsed(nonce)
+}
+
+// cleanupNonces removes expired nonces
+func (w *Webhook) cleanupNonces() {
+	w.nonces.cleanup()
+}
+
+// storeNonce stores a nonce with its timestamp
+func (w *Webhook) storeNonce(nonce string, timestamp time.Time) {
+	w.nonces.store(nonce, timestamp)
+}
 
-	if !exists {
-		return false
+// Name identifies this transport in Dispatcher results and logs.
+func (w *Webhook) Name() string {
+	return "webhook"
+}
+
+// offloadFindingsIfNeeded uploads payload.Findings to w.objectStore and replaces them with a
+// FindingsRef when their JSON encoding exceeds w.offloadThreshold, so the webhook body (and the
+// HMAC signature computed over it) stays well under receiver body-size and timeout limits. It's
+// a no-op when no ObjectStore is configured or the findings are small enough to inline.
+func (w *Webhook) offloadFindingsIfNeeded(ctx context.Context, payload *Payload) error {
+	if w.objectStore == nil || len(payload.Findings) == 0 {
+		return nil
 	}
 
-	// If the nonce has expired, remove it and return false
-	if time.Since(timestamp) > maxAge {
-		w.nonceMux.Lock()
-		delete(w.nonces, nonce)
-		w.nonceMux.Unlock()
-		return false
+	raw, err := json.Marshal(payload.Findings)
+	if err != nil {
+		return fmt.Errorf("failed to marshal findings: %v", err)
+	}
+	if len(raw) <= w.offloadThreshold {
+		return nil
 	}
 
-	return true
+	compressed, err := zstdCompress(raw)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(compressed)
+	hexSum := fmt.Sprintf("%x", sum)
+
+	key := fmt.Sprintf("runs/%s/%s.json.zst", payload.RunID, hexSum[:16])
+	url, err := w.objectStore.Put(ctx, key, compressed, "zstd")
+	if err != nil {
+		return fmt.Errorf("failed to upload findings: %v", err)
+	}
+
+	payload.FindingsCount = len(payload.Findings)
+	payload.FindingsRef = &FindingsRef{
+		URL:       url,
+		SHA256:    hexSum,
+		Bytes:     int64(len(compressed)),
+		ExpiresAt: time.Now().Add(offloadTTL),
+	}
+	payload.Findings = nil
+
+	return nil
 }
 
-// cleanupNonces removes expired nonces
-func (w *Webhook) cleanupNonces() {
-	w.nonceMux.Lock()
-	defer w.nonceMux.Unlock()
+// authorize asks w.authorizer for this destination's policy, returning (nil, nil) when
+// w.authorizer itself is nil (not just a StaticAuthorizer(nil)) so callers that build a Webhook
+// by hand rather than through NewWebhook still work without an authorizer configured.
+func (w *Webhook) authorize(ctx context.Context, payload *Payload) (*Authorization, error) {
+	if w.authorizer == nil {
+		return nil, nil
+	}
+	return w.authorizer.Authorize(ctx, payload.Repo, payload.RunID)
+}
 
-	now := time.Now()
-	for nonce, timestamp := range w.nonces {
-		if now.Sub(timestamp) > maxAge {
-			delete(w.nonces, nonce)
-		}
+// reauthorize drops any cached Authorization for payload's destination (if w.authorizer supports
+// it) and fetches a fresh one, used by Send after a 401 so a rotated secret takes effect on the
+// very next attempt instead of waiting out ExpiresAt.
+func (w *Webhook) reauthorize(ctx context.Context, payload *Payload) (*Authorization, error) {
+	if inv, ok := w.authorizer.(invalidator); ok {
+		inv.Invalidate(payload.Repo, payload.RunID)
 	}
+	return w.authorize(ctx, payload)
 }
 
-// storeNonce stores a nonce with its timestamp
-func (w *Webhook) storeNonce(nonce string, timestamp time.Time) {
-	w.nonceMux.Lock()
-	w.nonces[nonce] = timestamp
-	w.nonceMux.Unlock()
+// prepare signs and marshals payload under auth's policy (falling back to w.secret and
+// HMAC-SHA256 when auth is nil), returning the JSON body Send should POST.
+func (w *Webhook) prepare(payload *Payload, auth *Authorization) ([]byte, error) {
+	secret := w.secret
+	if auth != nil && auth.Secret != "" {
+		secret = []byte(auth.Secret)
+	}
+
+	signature, err := w.signPayloadWithSecret(payload, secret, auth.hmacAlgorithm())
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign payload: %v", err)
+	}
+	payload.Sign = signature
+
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payload: %v", err)
+	}
+
+	if auth != nil && auth.MaxPayloadBytes > 0 && int64(len(jsonPayload)) > auth.MaxPayloadBytes {
+		return nil, fmt.Errorf("payload of %d bytes exceeds destination limit of %d bytes", len(jsonPayload), auth.MaxPayloadBytes)
+	}
+
+	return jsonPayload, nil
+}
+
+// acquireLimiter blocks on w.limiter (if configured) before Send dials the destination,
+// returning a no-op release when no limiter is set so every call site can treat it uniformly.
+func (w *Webhook) acquireLimiter(ctx context.Context) (func(), error) {
+	if w.limiter == nil {
+		return func() {}, nil
+	}
+	return w.limiter.Wait(ctx)
 }
 
 // Send sends a webhook alert with the given findings
-func (w *Webhook) Send(payload *Payload) error {
+func (w *Webhook) Send(ctx context.Context, payload *Payload) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	// Check if payload is too old
 	if time.Since(payload.GeneratedAt) > maxAge {
 		return fmt.Errorf("payload timestamp expired")
 	}
 
+	auth, err := w.authorize(ctx, payload)
+	if err != nil {
+		return fmt.Errorf("failed to authorize webhook destination: %v", err)
+	}
+	if auth != nil && auth.AllowedSeverityFloor != "" && severityRank[worstSeverity(payload.Findings)] < severityRank[auth.AllowedSeverityFloor] {
+		return nil // suppressed by destination policy, below its allowed severity floor
+	}
+
+	if err := w.offloadFindingsIfNeeded(ctx, payload); err != nil {
+		return fmt.Errorf("failed to offload findings: %v", err)
+	}
+
 	// Generate and set nonce
 	nonce, err := w.generateNonce()
 	if err != nil {
@@ -135,12 +299,10 @@ func (w *Webhook) Send(payload *Payload) error {
 	}
 	payload.Nonce = nonce
 
-	// Sign the payload
-	signature, err := w.signPayload(payload)
+	jsonPayload, err := w.prepare(payload, auth)
 	if err != nil {
-		return fmt.Errorf("failed to sign payload: %v", err)
+		return err
 	}
-	payload.Sign = signature
 
 	// Check for replay attack
 	if w.isNonceUsed(nonce) {
@@ -153,33 +315,47 @@ func (w *Webhook) Send(payload *Payload) error {
 	// Periodically cleanup old nonces
 	go w.cleanupNonces()
 
-	// Marshal payload
-	jsonPayload, err := json.Marshal(payload)
-	if err != nil {
-		return fmt.Errorf("failed to marshal payload: %v", err)
-	}
-
 	// Send with retries
 	var lastErr error
 	for i := 0; i < maxRetries; i++ {
-		req, err := http.NewRequest("POST", w.url, bytes.NewReader(jsonPayload))
+		req, err := http.NewRequestWithContext(ctx, "POST", w.url, bytes.NewReader(jsonPayload))
 		if err != nil {
 			return fmt.Errorf("failed to create request: %v", err)
 		}
 		req.Header.Set("Content-Type", "application/json")
 
+		release, err := w.acquireLimiter(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to acquire rate limiter: %v", err)
+		}
+
 		resp, err := w.client.Do(req)
+		release()
 		if err != nil {
 			lastErr = err
 			time.Sleep(time.Duration(i+1) * baseDelay)
 			continue
 		}
-		defer resp.Body.Close()
+		resp.Body.Close()
+
+		if w.limiter != nil {
+			w.limiter.AdjustFromResponse(resp)
+		}
 
 		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
 			return nil
 		}
 
+		if resp.StatusCode == http.StatusUnauthorized {
+			if auth, err = w.reauthorize(ctx, payload); err == nil {
+				if jsonPayload, err = w.prepare(payload, auth); err == nil {
+					lastErr = fmt.Errorf("server returned status %d", http.StatusUnauthorized)
+					time.Sleep(time.Duration(i+1) * baseDelay)
+					continue
+				}
+			}
+		}
+
 		lastErr = fmt.Errorf("server returned status %d", resp.StatusCode)
 		time.Sleep(time.Duration(i+1) * baseDelay)
 	}
@@ -187,8 +363,34 @@ func (w *Webhook) Send(payload *Payload) error {
 	return fmt.Errorf("failed after %d retries: %v", maxRetries, lastErr)
 }
 
-// signPayload creates an HMAC-SHA256 signature for the payload
+// newHMAC returns the hash.Hash constructor a Signature.Algorithm value names.
+func newHMAC(algorithm string) (func() hash.Hash, error) {
+	switch algorithm {
+	case "", "HMAC-SHA256":
+		return sha256.New, nil
+	case "HMAC-SHA512":
+		return sha512.New, nil
+	default:
+		return nil, fmt.Errorf("unsupported signature algorithm: %s", algorithm)
+	}
+}
+
+// signPayload creates an HMAC-SHA256 signature for the payload using w.secret. It's a thin
+// wrapper around signPayloadWithSecret for callers (and tests) that don't go through the
+// pre-authorize handshake in authorizer.go.
 func (w *Webhook) signPayload(payload *Payload) (*Signature, error) {
+	return w.signPayloadWithSecret(payload, w.secret, "HMAC-SHA256")
+}
+
+// signPayloadWithSecret creates an HMAC signature for payload under secret and algorithm (one of
+// Signature's Algorithm values), so Send can sign under a destination's authorized secret
+// (see Webhook.prepare) without mutating shared Webhook state.
+func (w *Webhook) signPayloadWithSecret(payload *Payload, secret []byte, algorithm string) (*Signature, error) {
+	newHash, err := newHMAC(algorithm)
+	if err != nil {
+		return nil, err
+	}
+
 	// Clear existing signature for signing
 	origSig := payload.Sign
 	payload.Sign = nil
@@ -204,17 +406,18 @@ func (w *Webhook) signPayload(payload *Payload) (*Signature, error) {
 	payload.Sign = origSig
 
 	// Create HMAC
-	mac := hmac.New(sha256.New, w.secret)
+	mac := hmac.New(newHash, secret)
 	mac.Write(data)
 	signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
 
 	return &Signature{
-		Algorithm: "HMAC-SHA256",
+		Algorithm: algorithm,
 		Value:     signature,
 	}, nil
 }
 
-// verifySignature verifies the HMAC signature of a payload
+// verifySignature verifies the HMAC signature of a payload against w.secret, supporting both
+// HMAC-SHA256 (the default) and HMAC-SHA512 (see Authorization.HMACAlgorithm).
 func (w *Webhook) verifySignature(payload *Payload) error {
 	// Check if payload is too old
 	if time.Since(payload.GeneratedAt) > maxAge {
@@ -225,8 +428,9 @@ func (w *Webhook) verifySignature(payload *Payload) error {
 		return fmt.Errorf("no signature provided")
 	}
 
-	if payload.Sign.Algorithm != "HMAC-SHA256" {
-		return fmt.Errorf("unsupported signature algorithm: %s", payload.Sign.Algorithm)
+	newHash, err := newHMAC(payload.Sign.Algorithm)
+	if err != nil {
+		return err
 	}
 
 	// Store and clear signature for verification
@@ -244,7 +448,7 @@ func (w *Webhook) verifySignature(payload *Payload) error {
 	payload.Sign = origSig
 
 	// Create HMAC
-	mac := hmac.New(sha256.New, w.secret)
+	mac := hmac.New(newHash, w.secret)
 	mac.Write(data)
 	expectedSig := base64.StdEncoding.EncodeToString(mac.Sum(nil))
 
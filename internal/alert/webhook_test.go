@@ -1,6 +1,7 @@
 package alert
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -13,6 +14,7 @@ import (
 
 	"github.com/ejagojo/SentryScan/internal/scanner"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestWebhookRetry(t *testing.T) {
@@ -37,7 +39,7 @@ func TestWebhookRetry(t *testing.T) {
 		GeneratedAt: time.Now(),
 	}
 
-	err := wh.Send(payload)
+	err := wh.Send(context.Background(), payload)
 	assert.NoError(t, err)
 	assert.Equal(t, 3, attempts, "Expected 3 attempts before success")
 }
@@ -76,7 +78,7 @@ func TestWebhookReplayAttack(t *testing.T) {
 		GitRef:      "main",
 		GeneratedAt: time.Now(),
 	}
-	err := wh.Send(payload)
+	err := wh.Send(context.Background(), payload)
 	assert.NoError(t, err)
 
 	// Immediate replay should fail
@@ -88,7 +90,7 @@ func TestWebhookReplayAttack(t *testing.T) {
 		GitRef:      "main",
 		GeneratedAt: time.Now(),
 	}
-	err = wh.Send(replayPayload)
+	err = wh.Send(context.Background(), replayPayload)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "replay attack detected")
 
@@ -105,7 +107,7 @@ func TestWebhookReplayAttack(t *testing.T) {
 		GitRef:      "main",
 		GeneratedAt: time.Now(),
 	}
-	err = wh.Send(newPayload)
+	err = wh.Send(context.Background(), newPayload)
 	assert.NoError(t, err)
 }
 
@@ -148,7 +150,7 @@ func TestWebhookSignature(t *testing.T) {
 	}
 
 	t.Log("Sending valid payload")
-	err := wh1.Send(validPayload)
+	err := wh1.Send(context.Background(), validPayload)
 	if err != nil {
 		t.Logf("Error sending valid payload: %v", err)
 	}
@@ -169,7 +171,7 @@ func TestWebhookSignature(t *testing.T) {
 	}
 
 	t.Log("Sending tampered payload")
-	err = wh2.Send(tamperedPayload)
+	err = wh2.Send(context.Background(), tamperedPayload)
 	if err != nil {
 		t.Logf("Error sending tampered payload: %v", err)
 	}
@@ -191,7 +193,7 @@ func TestWebhookSignature(t *testing.T) {
 	}
 
 	t.Log("Sending expired payload")
-	err = wh3.Send(expiredPayload)
+	err = wh3.Send(context.Background(), expiredPayload)
 	if err != nil {
 		t.Logf("Error sending expired payload: %v", err)
 	}
@@ -250,7 +252,7 @@ func TestWebhookConcurrency(t *testing.T) {
 				GitRef:      "main",
 				GeneratedAt: time.Now(),
 			}
-			err := wh.Send(payload)
+			err := wh.Send(context.Background(), payload)
 			if err != nil {
 				t.Errorf("concurrent webhook %d failed: %v", i, err)
 			}
@@ -359,7 +361,7 @@ func TestWebhookPayloadValidation(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := wh.Send(tt.payload)
+			err := wh.Send(context.Background(), tt.payload)
 			if tt.wantError {
 				assert.Error(t, err)
 			} else {
@@ -386,7 +388,7 @@ func TestWebhookTimeout(t *testing.T) {
 		GeneratedAt: time.Now(),
 	}
 
-	err := wh.Send(payload)
+	err := wh.Send(context.Background(), payload)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "timeout")
 }
@@ -402,10 +404,74 @@ func TestWebhookInvalidURL(t *testing.T) {
 		GeneratedAt: time.Now(),
 	}
 
-	err := wh.Send(payload)
+	err := wh.Send(context.Background(), payload)
 	assert.Error(t, err)
 }
 
+func TestWebhookSend_SmallFindingsNotOffloaded(t *testing.T) {
+	var decoded Payload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&decoded))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	store := &fileObjectStore{dir: t.TempDir()}
+	wh := NewWebhook(server.URL, "test-secret", WithObjectStore(store))
+
+	payload := &Payload{
+		RunID:       "test-run",
+		Summary:     "test summary",
+		Findings:    []scanner.Finding{{RuleID: "aws-access-key"}},
+		Repo:        "test/repo",
+		GitRef:      "main",
+		GeneratedAt: time.Now(),
+	}
+
+	err := wh.Send(context.Background(), payload)
+	require.NoError(t, err)
+	assert.Nil(t, decoded.FindingsRef)
+	assert.Len(t, decoded.Findings, 1)
+}
+
+func TestWebhookSend_LargeFindingsOffloaded(t *testing.T) {
+	var decoded Payload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&decoded))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	storeDir := t.TempDir()
+	store := &fileObjectStore{dir: storeDir}
+	wh := NewWebhook(server.URL, "test-secret", WithObjectStore(store), WithOffloadThreshold(16))
+
+	findings := make([]scanner.Finding, 50)
+	for i := range findings {
+		findings[i] = scanner.Finding{RuleID: fmt.Sprintf("rule-%d", i), Path: "a.env", Line: i}
+	}
+
+	payload := &Payload{
+		RunID:       "test-run",
+		Summary:     "test summary",
+		Findings:    findings,
+		Repo:        "test/repo",
+		GitRef:      "main",
+		GeneratedAt: time.Now(),
+	}
+
+	err := wh.Send(context.Background(), payload)
+	require.NoError(t, err)
+
+	require.NotNil(t, decoded.FindingsRef)
+	assert.Empty(t, decoded.Findings)
+	assert.Equal(t, len(findings), decoded.FindingsCount)
+	assert.True(t, strings.HasPrefix(decoded.FindingsRef.URL, "file://"+storeDir))
+	assert.NotEmpty(t, decoded.FindingsRef.SHA256)
+	assert.Greater(t, decoded.FindingsRef.Bytes, int64(0))
+	assert.True(t, decoded.FindingsRef.ExpiresAt.After(time.Now()))
+}
+
 func TestWebhookNonceExpiry(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -422,7 +488,7 @@ func TestWebhookNonceExpiry(t *testing.T) {
 		GeneratedAt: time.Now().Add(-24 * time.Hour),
 	}
 
-	err := wh.Send(payload)
+	err := wh.Send(context.Background(), payload)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "expired")
 }
@@ -6,40 +6,70 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
+	wfp "github.com/ejagojo/SentryScan/internal/fingerprint"
 	"github.com/ejagojo/SentryScan/internal/scanner"
 )
 
 const (
 	baselineFileName = ".sentryscan_baseline.json"
+
+	// currentSchemaVersion is bumped whenever the Finding shape or fingerprinting scheme
+	// changes. v1 baselines (no schemaVersion field, exact RuleID+Path+Line fingerprint only)
+	// are upgraded in place by migrateV1 on Load. v3 adds FileFingerprint/LineFingerprint;
+	// migrateV1 also upgrades v2 baselines, with the same no-op bump - old entries keep matching
+	// on whatever hashes they have and only gain the new one once re-added.
+	currentSchemaVersion = 3
+
+	// contextWindow is how many lines above and below a finding are folded into ContextHash,
+	// so a baseline entry survives the finding's line shifting by a few lines.
+	contextWindow = 3
 )
 
 // Baseline represents the suppression file
 type Baseline struct {
-	Version   string    `json:"version"`
-	CreatedBy string    `json:"createdBy"`
-	CreatedAt time.Time `json:"createdAt"`
-	Findings  []Finding `json:"findings"`
+	Version       string    `json:"version"`
+	SchemaVersion int       `json:"schemaVersion"`
+	CreatedBy     string    `json:"createdBy"`
+	CreatedAt     time.Time `json:"createdAt"`
+	Findings      []Finding `json:"findings"`
 }
 
-// Finding represents a suppressed finding
+// Finding represents a suppressed finding. ContextHash and ShapeHash are populated from
+// schemaVersion 2 onward, FileFingerprint/LineFingerprint from schemaVersion 3 onward; entries
+// migrated up from an older baseline leave the newer fields blank, since the surrounding source
+// at the time the entry was added is no longer recoverable.
 type Finding struct {
 	RuleID      string `json:"ruleId"`
 	Path        string `json:"path"`
 	Line        int    `json:"line"`
 	Fingerprint string `json:"fingerprint"`
+	ContextHash string `json:"contextHash,omitempty"`
+	ShapeHash   string `json:"shapeHash,omitempty"`
+
+	// FileFingerprint is the whole-file MD5 (internal/fingerprint) of Path at the time this
+	// entry was added. LineFingerprint is the MD5 of Line's normalized content within that file.
+	// Together they key a suppression on (RuleID, FileFingerprint, LineFingerprint). That key
+	// matches only while the file's raw bytes are unchanged: moving the line, or any other edit
+	// to the file, changes FileFingerprint, so the key no longer matches. Editing the secret
+	// itself changes LineFingerprint as well, so the finding resurfaces.
+	FileFingerprint string `json:"fileFingerprint,omitempty"`
+	LineFingerprint string `json:"lineFingerprint,omitempty"`
 }
 
-// Load loads the baseline file from the given directory
+// Load loads the baseline file from the given directory, migrating it to currentSchemaVersion
+// if it was written by an older version of SentryScan.
 func Load(dir string) (*Baseline, error) {
 	path := filepath.Join(dir, baselineFileName)
 	data, err := os.ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return &Baseline{
-				Version:   "1.0",
-				CreatedAt: time.Now(),
+				Version:       "1.0",
+				SchemaVersion: currentSchemaVersion,
+				CreatedAt:     time.Now(),
 			}, nil
 		}
 		return nil, fmt.Errorf("failed to read baseline file: %w", err)
@@ -50,9 +80,20 @@ func Load(dir string) (*Baseline, error) {
 		return nil, fmt.Errorf("failed to parse baseline file: %w", err)
 	}
 
+	if baseline.SchemaVersion < currentSchemaVersion {
+		migrateV1(&baseline)
+	}
+
 	return &baseline, nil
 }
 
+// migrateV1 upgrades a pre-currentSchemaVersion baseline in place. It's a one-shot bump: older
+// entries keep matching via whichever hashes they already have, they just don't gain the newer
+// ones (ContextHash/ShapeHash, then FileFingerprint/LineFingerprint) until they're re-added.
+func migrateV1(b *Baseline) {
+	b.SchemaVersion = currentSchemaVersion
+}
+
 // Save saves the baseline file to the given directory
 func (b *Baseline) Save(dir string) error {
 	path := filepath.Join(dir, baselineFileName)
@@ -73,23 +114,49 @@ func (b *Baseline) Add(finding scanner.Finding) error {
 		}
 	}
 
+	fileFP, lineFP := fileAndLineFingerprint(finding)
+
 	b.Findings = append(b.Findings, Finding{
-		RuleID:      finding.RuleID,
-		Path:        finding.Path,
-		Line:        finding.Line,
-		Fingerprint: fp,
+		RuleID:          finding.RuleID,
+		Path:            finding.Path,
+		Line:            finding.Line,
+		Fingerprint:     fp,
+		ContextHash:     contextHash(finding),
+		ShapeHash:       shapeHash(finding),
+		FileFingerprint: fileFP,
+		LineFingerprint: lineFP,
 	})
 
 	return nil
 }
 
-// IsSuppressed checks if a finding is suppressed in the baseline
+// IsSuppressed reports whether finding is covered by the baseline. A finding is suppressed if
+// any entry matches it by (a) exact fingerprint, (b) ContextHash within the same file - so the
+// finding shifting by a few lines doesn't un-suppress it - (c) ShapeHash for the same rule
+// anywhere in the repo, which catches the same secret literal copy-pasted to a new location, or
+// (d) the same (RuleID, FileFingerprint, LineFingerprint) tuple, which catches the same line in
+// a byte-identical file under any path. Since FileFingerprint covers the whole file, (d) does not
+// match once the line has moved or the file has been edited in any other way.
 func (b *Baseline) IsSuppressed(finding scanner.Finding) bool {
 	fp := fingerprint(finding)
+	ctxHash := contextHash(finding)
+	shpHash := shapeHash(finding)
+	normalizedPath := normalizePath(finding.Path)
+	fileFP, lineFP := fileAndLineFingerprint(finding)
+
 	for _, f := range b.Findings {
 		if f.Fingerprint == fp {
 			return true
 		}
+		if ctxHash != "" && f.ContextHash == ctxHash && normalizePath(f.Path) == normalizedPath {
+			return true
+		}
+		if shpHash != "" && f.ShapeHash == shpHash && f.RuleID == finding.RuleID {
+			return true
+		}
+		if fileFP != "" && lineFP != "" && f.FileFingerprint == fileFP && f.LineFingerprint == lineFP && f.RuleID == finding.RuleID {
+			return true
+		}
 	}
 	return false
 }
@@ -105,7 +172,8 @@ func (b *Baseline) Filter(findings []scanner.Finding) []scanner.Finding {
 	return filtered
 }
 
-// fingerprint generates a unique fingerprint for a finding
+// fingerprint generates the exact RuleID+Path+Line fingerprint for a finding. This is the
+// original (schemaVersion 1) fingerprint, kept as-is so old baseline entries keep matching.
 func fingerprint(finding scanner.Finding) string {
 	h := sha256.New()
 	h.Write([]byte(finding.RuleID))
@@ -113,3 +181,98 @@ func fingerprint(finding scanner.Finding) string {
 	h.Write([]byte(fmt.Sprintf("%d", finding.Line)))
 	return fmt.Sprintf("%x", h.Sum(nil))
 }
+
+// contextHash hashes RuleID, the normalized path, and a whitespace-collapsed ±contextWindow
+// window of source around the finding's line, so a suppression survives the file growing or
+// shrinking by a few lines elsewhere. Returns "" if the source file can no longer be read.
+func contextHash(finding scanner.Finding) string {
+	window, err := readContextWindow(finding.Path, finding.Line)
+	if err != nil {
+		return ""
+	}
+
+	h := sha256.New()
+	h.Write([]byte(finding.RuleID))
+	h.Write([]byte(normalizePath(finding.Path)))
+	h.Write([]byte(window))
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// readContextWindow reads the lines [line-contextWindow, line+contextWindow] (1-indexed,
+// clamped to the file's bounds) around line in path, collapses each line's whitespace, and
+// joins them with "\n".
+func readContextWindow(path string, line int) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	lines := strings.Split(string(data), "\n")
+	start := line - 1 - contextWindow
+	if start < 0 {
+		start = 0
+	}
+	end := line - 1 + contextWindow + 1
+	if end > len(lines) {
+		end = len(lines)
+	}
+	if start >= end {
+		return "", fmt.Errorf("line %d out of range", line)
+	}
+
+	collapsed := make([]string, 0, end-start)
+	for _, l := range lines[start:end] {
+		collapsed = append(collapsed, strings.Join(strings.Fields(l), " "))
+	}
+
+	return strings.Join(collapsed, "\n"), nil
+}
+
+// shapeHash hashes RuleID and a redacted "shape" of the matched secret - each character
+// replaced by a class marker ('A' for upper, 'a' for lower, '9' for digit, '*' otherwise) -
+// preserving length and character-class layout without preserving the secret itself.
+func shapeHash(finding scanner.Finding) string {
+	if finding.Match == "" {
+		return ""
+	}
+
+	h := sha256.New()
+	h.Write([]byte(finding.RuleID))
+	h.Write([]byte(maskShape(finding.Match)))
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// maskShape redacts s into a string of the same length where each rune is replaced by a marker
+// for its character class, e.g. "AKIAXXXX1234" -> "AAAAAAAA9999".
+func maskShape(s string) string {
+	shape := make([]rune, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r >= 'A' && r <= 'Z':
+			shape = append(shape, 'A')
+		case r >= 'a' && r <= 'z':
+			shape = append(shape, 'a')
+		case r >= '0' && r <= '9':
+			shape = append(shape, '9')
+		default:
+			shape = append(shape, '*')
+		}
+	}
+	return string(shape)
+}
+
+// fileAndLineFingerprint computes finding's WFP-style file and line fingerprints (see
+// internal/fingerprint), returning ("", "") if the source file can no longer be read.
+func fileAndLineFingerprint(finding scanner.Finding) (file, line string) {
+	fp, err := wfp.Compute(finding.Path)
+	if err != nil {
+		return "", ""
+	}
+	return fp.MD5, fp.LineFingerprint(finding.Line)
+}
+
+// normalizePath puts path into a canonical, OS-independent form so baseline entries written on
+// one platform still match findings scanned on another.
+func normalizePath(path string) string {
+	return filepath.ToSlash(filepath.Clean(path))
+}
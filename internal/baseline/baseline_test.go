@@ -0,0 +1,136 @@
+package baseline
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ejagojo/SentryScan/internal/scanner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_MissingFileReturnsEmptyBaseline(t *testing.T) {
+	dir := t.TempDir()
+
+	b, err := Load(dir)
+	require.NoError(t, err)
+	assert.Equal(t, currentSchemaVersion, b.SchemaVersion)
+	assert.Empty(t, b.Findings)
+}
+
+func TestSaveAndLoad_RoundTrips(t *testing.T) {
+	dir := t.TempDir()
+
+	b := &Baseline{Version: "1.0", SchemaVersion: currentSchemaVersion}
+	require.NoError(t, b.Add(scanner.Finding{RuleID: "aws-access-key", Path: "a.txt", Line: 1}))
+	require.NoError(t, b.Save(dir))
+
+	loaded, err := Load(dir)
+	require.NoError(t, err)
+	require.Len(t, loaded.Findings, 1)
+	assert.Equal(t, "aws-access-key", loaded.Findings[0].RuleID)
+}
+
+func TestLoad_MigratesOldSchemaVersion(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, baselineFileName)
+	require.NoError(t, os.WriteFile(path, []byte(`{"version":"1.0","findings":[{"ruleId":"x","path":"a.txt","line":1}]}`), 0644))
+
+	b, err := Load(dir)
+	require.NoError(t, err)
+	assert.Equal(t, currentSchemaVersion, b.SchemaVersion)
+	assert.Len(t, b.Findings, 1)
+}
+
+func TestLoad_InvalidJSON(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, baselineFileName)
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0644))
+
+	_, err := Load(dir)
+	assert.Error(t, err)
+}
+
+func TestBaseline_Add_RejectsDuplicate(t *testing.T) {
+	b := &Baseline{}
+	finding := scanner.Finding{RuleID: "x", Path: "a.txt", Line: 1}
+
+	require.NoError(t, b.Add(finding))
+	err := b.Add(finding)
+	assert.Error(t, err)
+}
+
+func TestBaseline_IsSuppressed_ExactFingerprint(t *testing.T) {
+	b := &Baseline{}
+	finding := scanner.Finding{RuleID: "x", Path: "a.txt", Line: 5}
+	require.NoError(t, b.Add(finding))
+
+	assert.True(t, b.IsSuppressed(finding))
+	assert.False(t, b.IsSuppressed(scanner.Finding{RuleID: "x", Path: "a.txt", Line: 6}))
+}
+
+func TestBaseline_IsSuppressed_ContextHashSurvivesLineShift(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "source.txt")
+
+	require.NoError(t, os.WriteFile(path, []byte("header\npre1\npre2\nSECRETLINE\npost1\npost2\n"), 0644))
+
+	b := &Baseline{}
+	original := scanner.Finding{RuleID: "x", Path: path, Line: 4}
+	require.NoError(t, b.Add(original))
+
+	// A line gets inserted above the secret, shifting it down by one with the surrounding
+	// context (the actual basis for ContextHash) unchanged.
+	require.NoError(t, os.WriteFile(path, []byte("inserted\nheader\npre1\npre2\nSECRETLINE\npost1\npost2\n"), 0644))
+
+	shifted := scanner.Finding{RuleID: "x", Path: path, Line: 5}
+	assert.True(t, b.IsSuppressed(shifted))
+}
+
+func TestBaseline_IsSuppressed_ShapeHashMatchesCopiedSecret(t *testing.T) {
+	b := &Baseline{}
+	original := scanner.Finding{RuleID: "aws-access-key", Path: "a.txt", Line: 1, Match: "AKIAABCDEFGH1234"}
+	require.NoError(t, b.Add(original))
+
+	moved := scanner.Finding{RuleID: "aws-access-key", Path: "b.txt", Line: 99, Match: "AKIAWXYZABCD5678"}
+	assert.True(t, b.IsSuppressed(moved), "same shape, same rule, different location should still suppress")
+
+	differentRule := scanner.Finding{RuleID: "other-rule", Path: "b.txt", Line: 99, Match: "AKIAWXYZABCD5678"}
+	assert.False(t, b.IsSuppressed(differentRule))
+}
+
+func TestBaseline_IsSuppressed_FileAndLineFingerprint(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "source.txt")
+	// "secretvalue" appears twice, so the same LineFingerprint shows up at two different
+	// line numbers without any edit to the file (FileFingerprint stays identical too).
+	require.NoError(t, os.WriteFile(path, []byte("a\nsecretvalue\nc\nd\nsecretvalue\n"), 0644))
+
+	b := &Baseline{}
+	original := scanner.Finding{RuleID: "x", Path: path, Line: 2}
+	require.NoError(t, b.Add(original))
+
+	movedCopy := scanner.Finding{RuleID: "x", Path: path, Line: 5}
+	assert.True(t, b.IsSuppressed(movedCopy))
+}
+
+func TestBaseline_Filter(t *testing.T) {
+	b := &Baseline{}
+	suppressed := scanner.Finding{RuleID: "x", Path: "a.txt", Line: 1}
+	require.NoError(t, b.Add(suppressed))
+
+	kept := scanner.Finding{RuleID: "y", Path: "b.txt", Line: 2}
+	filtered := b.Filter([]scanner.Finding{suppressed, kept})
+
+	require.Len(t, filtered, 1)
+	assert.Equal(t, kept, filtered[0])
+}
+
+func TestMaskShape(t *testing.T) {
+	assert.Equal(t, "AAAAaaaa9999***", maskShape("AKIAabcd1234-!@"))
+}
+
+func TestNormalizePath(t *testing.T) {
+	assert.Equal(t, "a/b/c.txt", normalizePath(filepath.FromSlash("a/b/../b/c.txt")))
+}
@@ -0,0 +1,235 @@
+// Package cache implements a persistent, content-addressed store that lets SentryScan skip
+// re-running rules against blobs it has already scanned, the same way restic's index avoids
+// re-reading unchanged chunks on a second backup: most bytes in a second scan of a monorepo
+// are unchanged, so a hash lookup replaces a full regex pass.
+package cache
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ejagojo/SentryScan/internal/scanner"
+)
+
+const dbFileName = "findings.db"
+
+// entry is a single append-only record: one blob's findings under one rules digest.
+type entry struct {
+	BlobSHA256  string            `json:"blob_sha256"`
+	RulesDigest string            `json:"rules_digest"`
+	Findings    []scanner.Finding `json:"findings"`
+	ScannedAt   time.Time         `json:"scanned_at"`
+}
+
+// Cache is an in-memory index over an append-only JSONL file on disk. It is safe for
+// concurrent use.
+type Cache struct {
+	mu     sync.Mutex
+	path   string
+	index  map[string]entry // keyed by BlobSHA256
+	digest string
+}
+
+// DefaultPath returns $XDG_CACHE_HOME/sentryscan/findings.db, falling back to
+// ~/.cache/sentryscan/findings.db.
+func DefaultPath() string {
+	base := os.Getenv("XDG_CACHE_HOME")
+	if base == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return filepath.Join(".cache", "sentryscan", dbFileName)
+		}
+		base = filepath.Join(home, ".cache")
+	}
+	return filepath.Join(base, "sentryscan", dbFileName)
+}
+
+// RulesDigest hashes the (RuleID, Pattern, Severity) tuples of a rule set, sorted by ID, so
+// the cache can be invalidated whenever the active ruleset changes shape.
+func RulesDigest(rules []scanner.Rule) string {
+	type tuple struct {
+		ID, Pattern, Severity string
+	}
+	tuples := make([]tuple, len(rules))
+	for i, r := range rules {
+		pattern := ""
+		if r.Pattern != nil {
+			pattern = r.Pattern.String()
+		}
+		tuples[i] = tuple{r.ID, pattern, r.Severity}
+	}
+	sort.Slice(tuples, func(i, j int) bool { return tuples[i].ID < tuples[j].ID })
+
+	h := sha256.New()
+	for _, t := range tuples {
+		fmt.Fprintf(h, "%s\x00%s\x00%s\x00", t.ID, t.Pattern, t.Severity)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Open loads path (creating its parent directory if necessary) and builds the in-memory
+// index. rulesDigest identifies the active rule set; entries recorded under a different
+// digest are treated as misses rather than being evicted eagerly (Prune removes them).
+func Open(path, rulesDigest string) (*Cache, error) {
+	if path == "" {
+		path = DefaultPath()
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create cache dir: %w", err)
+	}
+
+	c := &Cache{path: path, index: make(map[string]entry), digest: rulesDigest}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return c, nil
+		}
+		return nil, fmt.Errorf("failed to open cache: %w", err)
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for sc.Scan() {
+		var e entry
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			continue // tolerate a truncated trailing record
+		}
+		c.index[e.BlobSHA256] = e
+	}
+
+	return c, nil
+}
+
+// HashBlob computes the content hash used to key cache entries. Scanner callers that already
+// compute a whole-blob hash for Fingerprint purposes should reuse that value instead of
+// calling this a second time.
+func HashBlob(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// Lookup returns the cached findings for blobSHA256, or (nil, false) on a miss — including a
+// "stale" miss where the entry exists but was recorded under a different rules digest.
+func (c *Cache) Lookup(blobSHA256 string) ([]scanner.Finding, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.index[blobSHA256]
+	if !ok || e.RulesDigest != c.digest {
+		return nil, false
+	}
+	return e.Findings, true
+}
+
+// Store appends a new entry for blobSHA256 and updates the in-memory index. The on-disk
+// file is append-only; Prune is responsible for compacting it.
+func (c *Cache) Store(blobSHA256 string, findings []scanner.Finding) error {
+	e := entry{
+		BlobSHA256:  blobSHA256,
+		RulesDigest: c.digest,
+		Findings:    findings,
+		ScannedAt:   time.Now(),
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	f, err := os.OpenFile(c.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to append to cache: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	c.index[blobSHA256] = e
+	return nil
+}
+
+// Stats summarizes the cache contents.
+type Stats struct {
+	Entries   int
+	StaleDigest int
+	OldestScan  time.Time
+	NewestScan  time.Time
+}
+
+// Stats reports summary counts for `sentryscan cache stats`.
+func (c *Cache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var s Stats
+	for _, e := range c.index {
+		s.Entries++
+		if e.RulesDigest != c.digest {
+			s.StaleDigest++
+		}
+		if s.OldestScan.IsZero() || e.ScannedAt.Before(s.OldestScan) {
+			s.OldestScan = e.ScannedAt
+		}
+		if e.ScannedAt.After(s.NewestScan) {
+			s.NewestScan = e.ScannedAt
+		}
+	}
+	return s
+}
+
+// Prune rewrites the cache file, dropping entries older than olderThan and entries recorded
+// under a stale rules digest, and returns how many entries were removed.
+func (c *Cache) Prune(olderThan time.Duration) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cutoff := time.Now().Add(-olderThan)
+	kept := make(map[string]entry, len(c.index))
+	removed := 0
+
+	for k, e := range c.index {
+		if e.RulesDigest != c.digest || e.ScannedAt.Before(cutoff) {
+			removed++
+			continue
+		}
+		kept[k] = e
+	}
+
+	tmp := c.path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create temp cache file: %w", err)
+	}
+
+	enc := json.NewEncoder(f)
+	for _, e := range kept {
+		if err := enc.Encode(e); err != nil {
+			f.Close()
+			os.Remove(tmp)
+			return 0, err
+		}
+	}
+	if err := f.Close(); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(tmp, c.path); err != nil {
+		return 0, err
+	}
+
+	c.index = kept
+	return removed, nil
+}
@@ -0,0 +1,137 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ejagojo/SentryScan/internal/scanner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpen_MissingFileStartsEmpty(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "findings.db")
+
+	c, err := Open(path, "digest-1")
+	require.NoError(t, err)
+	assert.Equal(t, Stats{}, c.Stats())
+}
+
+func TestCache_StoreAndLookup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "findings.db")
+	c, err := Open(path, "digest-1")
+	require.NoError(t, err)
+
+	findings := []scanner.Finding{{RuleID: "aws-access-key", Line: 3}}
+	require.NoError(t, c.Store("blob-sha", findings))
+
+	got, ok := c.Lookup("blob-sha")
+	require.True(t, ok)
+	assert.Equal(t, findings, got)
+
+	_, ok = c.Lookup("missing-blob")
+	assert.False(t, ok)
+}
+
+func TestCache_LookupMissesOnStaleDigest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "findings.db")
+	c, err := Open(path, "digest-1")
+	require.NoError(t, err)
+	require.NoError(t, c.Store("blob-sha", []scanner.Finding{{RuleID: "x"}}))
+
+	reopened, err := Open(path, "digest-2")
+	require.NoError(t, err)
+
+	_, ok := reopened.Lookup("blob-sha")
+	assert.False(t, ok, "an entry recorded under a different rules digest is a miss")
+}
+
+func TestOpen_ReloadsPersistedEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "findings.db")
+	c, err := Open(path, "digest-1")
+	require.NoError(t, err)
+	require.NoError(t, c.Store("blob-sha", []scanner.Finding{{RuleID: "x"}}))
+
+	reopened, err := Open(path, "digest-1")
+	require.NoError(t, err)
+	got, ok := reopened.Lookup("blob-sha")
+	require.True(t, ok)
+	assert.Equal(t, "x", got[0].RuleID)
+}
+
+func TestOpen_TolerantOfTruncatedTrailingRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "findings.db")
+	c, err := Open(path, "digest-1")
+	require.NoError(t, err)
+	require.NoError(t, c.Store("good", []scanner.Finding{{RuleID: "x"}}))
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"blob_sha256":"truncated"`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	reopened, err := Open(path, "digest-1")
+	require.NoError(t, err)
+	_, ok := reopened.Lookup("good")
+	assert.True(t, ok)
+	_, ok = reopened.Lookup("truncated")
+	assert.False(t, ok)
+}
+
+func TestRulesDigest_StableAndOrderIndependent(t *testing.T) {
+	a := []scanner.Rule{{ID: "b", Severity: "low"}, {ID: "a", Severity: "high"}}
+	b := []scanner.Rule{{ID: "a", Severity: "high"}, {ID: "b", Severity: "low"}}
+
+	assert.Equal(t, RulesDigest(a), RulesDigest(b))
+}
+
+func TestRulesDigest_ChangesWithRules(t *testing.T) {
+	a := []scanner.Rule{{ID: "a", Severity: "high"}}
+	b := []scanner.Rule{{ID: "a", Severity: "low"}}
+
+	assert.NotEqual(t, RulesDigest(a), RulesDigest(b))
+}
+
+func TestHashBlob(t *testing.T) {
+	assert.Equal(t, HashBlob([]byte("hello")), HashBlob([]byte("hello")))
+	assert.NotEqual(t, HashBlob([]byte("hello")), HashBlob([]byte("world")))
+}
+
+func TestCache_Prune(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "findings.db")
+	c, err := Open(path, "digest-1")
+	require.NoError(t, err)
+	require.NoError(t, c.Store("fresh", []scanner.Finding{{RuleID: "x"}}))
+
+	removed, err := c.Prune(24 * time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, 0, removed)
+
+	removed, err = c.Prune(0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+
+	_, ok := c.Lookup("fresh")
+	assert.False(t, ok)
+}
+
+func TestCache_PruneDropsStaleDigest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "findings.db")
+	c, err := Open(path, "digest-1")
+	require.NoError(t, err)
+	require.NoError(t, c.Store("x", []scanner.Finding{{RuleID: "x"}}))
+
+	c2, err := Open(path, "digest-2")
+	require.NoError(t, err)
+	removed, err := c2.Prune(24 * time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+}
+
+func TestDefaultPath(t *testing.T) {
+	path := DefaultPath()
+	assert.Contains(t, path, filepath.Join("sentryscan", dbFileName))
+}
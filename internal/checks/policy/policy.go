@@ -0,0 +1,124 @@
+// Package policy grades a repository's security disclosure policy (SECURITY.md and its
+// common variants) against a rubric and reports gaps as scanner.Finding entries, so a missing
+// or weak policy shows up in the same report as everything else SentryScan finds.
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/ejagojo/SentryScan/internal/scanner"
+	"github.com/ejagojo/SentryScan/pkg/rules"
+)
+
+// defaultPaths are checked, in order, for a security policy file. docs/security.md and
+// .gitlab/SECURITY.md cover the GitHub and GitLab conventions respectively - GitLab also
+// resolves a security policy from a group-level ".gitlab" project, but SentryScan only ever
+// sees a single checkout, so that project's own SECURITY.md is checked under the same name.
+var defaultPaths = []string{
+	"SECURITY.md",
+	".github/SECURITY.md",
+	"docs/security.md",
+	".gitlab/SECURITY.md",
+}
+
+var (
+	contactPattern  = regexp.MustCompile(`(?i)[\w.+-]+@[\w-]+\.[\w.-]+|https?://\S+`)
+	timelinePattern = regexp.MustCompile(`(?i)\b\d+\s*(business\s+)?(day|hour|week)s?\b|disclosure\s+timeline`)
+	versionsPattern = regexp.MustCompile(`(?i)supported\s+versions|\|\s*version\s*\|`)
+)
+
+// minPolicyLength is the byte length below which a policy file is considered too thin to be
+// useful, regardless of what it mentions.
+const minPolicyLength = 200
+
+// Check looks for a security policy under repoRoot and grades whatever it finds against the
+// built-in rubric plus any cfg.Rubric entries. A missing file reports a single "policy-missing"
+// Finding; a present-but-weak file reports one Finding per failed rubric check.
+func Check(repoRoot string, cfg *rules.SecurityPolicyConfig) ([]scanner.Finding, error) {
+	paths := defaultPaths
+	if cfg != nil && len(cfg.Paths) > 0 {
+		paths = append(append([]string{}, defaultPaths...), cfg.Paths...)
+	}
+
+	policyPath, content, err := findPolicy(repoRoot, paths)
+	if err != nil {
+		return nil, err
+	}
+	if policyPath == "" {
+		return []scanner.Finding{newFinding("policy-missing", "no security policy found (checked "+joinPaths(paths)+")", "high", "")}, nil
+	}
+
+	var findings []scanner.Finding
+	if !contactPattern.Match(content) {
+		findings = append(findings, newFinding("policy-no-contact", "security policy has no email or URL contact", "medium", policyPath))
+	}
+	if !timelinePattern.Match(content) {
+		findings = append(findings, newFinding("policy-no-timeline", "security policy does not mention a disclosure timeline", "low", policyPath))
+	}
+	if !versionsPattern.Match(content) {
+		findings = append(findings, newFinding("policy-no-versions", "security policy has no supported-versions table", "low", policyPath))
+	}
+	if len(content) < minPolicyLength {
+		findings = append(findings, newFinding("policy-too-thin", "security policy is too short to be useful", "medium", policyPath))
+	}
+
+	if cfg != nil {
+		for _, rc := range cfg.Rubric {
+			re, err := regexp.Compile(rc.Pattern)
+			if err != nil {
+				continue
+			}
+			if !re.Match(content) {
+				findings = append(findings, newFinding(rc.ID, rc.Description, severityOrDefault(rc.Severity, "medium"), policyPath))
+			}
+		}
+	}
+
+	return findings, nil
+}
+
+// findPolicy returns the first of paths that exists under repoRoot, along with its contents.
+// It returns an empty path (not an error) when none of them exist.
+func findPolicy(repoRoot string, paths []string) (string, []byte, error) {
+	for _, p := range paths {
+		full := filepath.Join(repoRoot, p)
+		content, err := os.ReadFile(full)
+		if err == nil {
+			return p, content, nil
+		}
+		if !os.IsNotExist(err) {
+			return "", nil, err
+		}
+	}
+	return "", nil, nil
+}
+
+func newFinding(ruleID, description, severity, path string) scanner.Finding {
+	return scanner.Finding{
+		Type:        "security-policy",
+		RuleID:      ruleID,
+		Description: description,
+		Severity:    severity,
+		Path:        path,
+	}
+}
+
+func joinPaths(paths []string) string {
+	out := ""
+	for i, p := range paths {
+		if i > 0 {
+			out += ", "
+		}
+		out += p
+	}
+	return out
+}
+
+func severityOrDefault(configured, def string) string {
+	if configured != "" {
+		return configured
+	}
+	return def
+}
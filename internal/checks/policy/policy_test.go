@@ -0,0 +1,87 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ejagojo/SentryScan/pkg/rules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckMissingPolicy(t *testing.T) {
+	dir := t.TempDir()
+
+	findings, err := Check(dir, nil)
+	require.NoError(t, err)
+	require.Len(t, findings, 1)
+	assert.Equal(t, "policy-missing", findings[0].RuleID)
+}
+
+func TestCheckWeakPolicy(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "SECURITY.md"), []byte("# Security\n\nPlease report issues responsibly."), 0644))
+
+	findings, err := Check(dir, nil)
+	require.NoError(t, err)
+
+	ruleIDs := make(map[string]bool)
+	for _, f := range findings {
+		ruleIDs[f.RuleID] = true
+	}
+	assert.True(t, ruleIDs["policy-no-contact"])
+	assert.True(t, ruleIDs["policy-no-timeline"])
+	assert.True(t, ruleIDs["policy-no-versions"])
+	assert.True(t, ruleIDs["policy-too-thin"])
+}
+
+func TestCheckGoodPolicy(t *testing.T) {
+	dir := t.TempDir()
+	content := `# Security Policy
+
+## Supported Versions
+
+| Version | Supported |
+| ------- | --------- |
+| 1.x     | yes       |
+
+## Reporting a Vulnerability
+
+Please email security@example.com. We aim to respond within 3 business days and will
+coordinate a disclosure timeline with you before any public announcement. This policy is
+deliberately long enough to clear the minimum-length rubric check as well.
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "SECURITY.md"), []byte(content), 0644))
+
+	findings, err := Check(dir, nil)
+	require.NoError(t, err)
+	assert.Empty(t, findings)
+}
+
+func TestCheckCustomRubric(t *testing.T) {
+	dir := t.TempDir()
+	content := `# Security Policy
+
+## Supported Versions
+
+| Version | Supported |
+| ------- | --------- |
+| 1.x     | yes       |
+
+Report to security@example.com within 3 business days via our disclosure timeline process.
+This text exists purely to clear the built-in length and rubric checks above.
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "SECURITY.md"), []byte(content), 0644))
+
+	cfg := &rules.SecurityPolicyConfig{
+		Rubric: []rules.RubricCheck{
+			{ID: "policy-no-pgp-key", Pattern: `(?i)pgp key`, Description: "security policy has no PGP key reference", Severity: "low"},
+		},
+	}
+
+	findings, err := Check(dir, cfg)
+	require.NoError(t, err)
+	require.Len(t, findings, 1)
+	assert.Equal(t, "policy-no-pgp-key", findings[0].RuleID)
+}
@@ -0,0 +1,98 @@
+// Package fingerprint computes WFP-style whole-file and per-line fingerprints. They back the
+// `sentryscan fingerprint` subcommand, the .sentryscan.fingerprints.wfp output format, and the
+// (rule ID, file fingerprint, line fingerprint) key internal/baseline matches suppressions on.
+// The file fingerprint is an MD5 of the raw bytes, so that key matches only while the file is
+// byte-identical; a line fingerprint alone is unaffected by edits to other lines.
+package fingerprint
+
+import (
+	"crypto/md5"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileFingerprint is one file's whole-file MD5 plus a per-line MD5 of its normalized content.
+type FileFingerprint struct {
+	Path  string
+	Size  int64
+	MD5   string
+	Lines []string // 1-indexed: Lines[0] is line 1's MD5
+}
+
+// Compute reads path and builds its FileFingerprint: an MD5 of the raw bytes, plus an MD5 of
+// each line once normalized (see normalizeLine).
+func Compute(path string) (*FileFingerprint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	fp := &FileFingerprint{
+		Path: path,
+		Size: int64(len(data)),
+		MD5:  fmt.Sprintf("%x", md5.Sum(data)),
+	}
+
+	ext := filepath.Ext(path)
+	normalized := strings.ReplaceAll(string(data), "\r\n", "\n")
+	for _, line := range strings.Split(normalized, "\n") {
+		sum := md5.Sum([]byte(normalizeLine(line, ext)))
+		fp.Lines = append(fp.Lines, fmt.Sprintf("%x", sum))
+	}
+
+	return fp, nil
+}
+
+// LineFingerprint returns the MD5 for the 1-indexed line, or "" if line is out of range.
+func (fp *FileFingerprint) LineFingerprint(line int) string {
+	if line < 1 || line > len(fp.Lines) {
+		return ""
+	}
+	return fp.Lines[line-1]
+}
+
+// normalizeLine strips a trailing line comment recognized for ext, then collapses whitespace,
+// so the same logical line fingerprints identically after reformatting or a comment edit.
+func normalizeLine(line, ext string) string {
+	if marker := commentMarker(ext); marker != "" {
+		if i := strings.Index(line, marker); i >= 0 {
+			line = line[:i]
+		}
+	}
+	return strings.Join(strings.Fields(line), " ")
+}
+
+// commentMarker returns the single-line comment marker for ext's language, or "" if unknown.
+// It only needs to recognize common languages well enough to strip noise from line hashes, not
+// fully parse comment syntax (block comments, strings containing the marker, etc. are ignored).
+func commentMarker(ext string) string {
+	switch strings.ToLower(ext) {
+	case ".go", ".c", ".h", ".cc", ".cpp", ".hpp", ".java", ".js", ".ts", ".jsx", ".tsx", ".rs", ".swift", ".kt", ".cs":
+		return "//"
+	case ".py", ".rb", ".sh", ".bash", ".yaml", ".yml", ".toml", ".pl", ".r":
+		return "#"
+	case ".sql", ".lua":
+		return "--"
+	default:
+		return ""
+	}
+}
+
+// WriteWFP writes fingerprints in a WFP-style text format: a "file=" header line carrying the
+// whole-file MD5, size, and path, followed by one "<line>=<md5>" line per source line.
+func WriteWFP(w io.Writer, fingerprints []*FileFingerprint) error {
+	for _, fp := range fingerprints {
+		if _, err := fmt.Fprintf(w, "file=%s,%d,%s\n", fp.MD5, fp.Size, fp.Path); err != nil {
+			return err
+		}
+		for i, line := range fp.Lines {
+			if _, err := fmt.Fprintf(w, "%d=%s\n", i+1, line); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
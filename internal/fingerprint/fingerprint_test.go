@@ -0,0 +1,113 @@
+package fingerprint
+
+import (
+	"bytes"
+	"crypto/md5"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompute_WholeFileAndLineHashes(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "a.go")
+	content := "package a\n// a comment\nvar x = 1\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	fp, err := Compute(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, path, fp.Path)
+	assert.Equal(t, int64(len(content)), fp.Size)
+	assert.Equal(t, fmt.Sprintf("%x", md5.Sum([]byte(content))), fp.MD5)
+	require.Len(t, fp.Lines, 4) // 3 lines + trailing empty line after the final "\n"
+}
+
+func TestCompute_MissingFile(t *testing.T) {
+	_, err := Compute(filepath.Join(t.TempDir(), "missing.go"))
+	assert.Error(t, err)
+}
+
+func TestCompute_NormalizesCRLF(t *testing.T) {
+	dir := t.TempDir()
+	unixPath := filepath.Join(dir, "unix.txt")
+	winPath := filepath.Join(dir, "win.txt")
+	require.NoError(t, os.WriteFile(unixPath, []byte("line one\nline two\n"), 0644))
+	require.NoError(t, os.WriteFile(winPath, []byte("line one\r\nline two\r\n"), 0644))
+
+	unix, err := Compute(unixPath)
+	require.NoError(t, err)
+	win, err := Compute(winPath)
+	require.NoError(t, err)
+
+	assert.Equal(t, unix.Lines, win.Lines, "CRLF and LF line endings should fingerprint identically")
+}
+
+func TestFileFingerprint_LineFingerprint(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "a.txt")
+	require.NoError(t, os.WriteFile(path, []byte("one\ntwo\nthree\n"), 0644))
+
+	fp, err := Compute(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, fp.Lines[0], fp.LineFingerprint(1))
+	assert.Equal(t, fp.Lines[2], fp.LineFingerprint(3))
+	assert.Equal(t, "", fp.LineFingerprint(0), "out-of-range line should return empty string")
+	assert.Equal(t, "", fp.LineFingerprint(len(fp.Lines)+1), "out-of-range line should return empty string")
+}
+
+func TestCompute_NormalizesTrailingComment(t *testing.T) {
+	dir := t.TempDir()
+	pathA := filepath.Join(dir, "a.go")
+	pathB := filepath.Join(dir, "b.go")
+	require.NoError(t, os.WriteFile(pathA, []byte("var x = 1\n"), 0644))
+	require.NoError(t, os.WriteFile(pathB, []byte("var x = 1 // explains why\n"), 0644))
+
+	a, err := Compute(pathA)
+	require.NoError(t, err)
+	b, err := Compute(pathB)
+	require.NoError(t, err)
+
+	assert.Equal(t, a.Lines[0], b.Lines[0], "a trailing line comment should not change the line fingerprint")
+	assert.NotEqual(t, a.MD5, b.MD5, "the whole-file MD5 still reflects the raw bytes")
+}
+
+func TestCommentMarker(t *testing.T) {
+	assert.Equal(t, "//", commentMarker(".go"))
+	assert.Equal(t, "//", commentMarker(".JS"))
+	assert.Equal(t, "#", commentMarker(".py"))
+	assert.Equal(t, "--", commentMarker(".sql"))
+	assert.Equal(t, "", commentMarker(".txt"))
+}
+
+func TestNormalizeLine_CollapsesWhitespace(t *testing.T) {
+	assert.Equal(t, "var x = 1", normalizeLine("  var   x =  1  ", ".go"))
+}
+
+func TestWriteWFP(t *testing.T) {
+	fps := []*FileFingerprint{
+		{Path: "a.txt", Size: 10, MD5: "deadbeef", Lines: []string{"line1md5", "line2md5"}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteWFP(&buf, fps))
+
+	assert.Equal(t, "file=deadbeef,10,a.txt\n1=line1md5\n2=line2md5\n", buf.String())
+}
+
+func TestWriteWFP_MultipleFiles(t *testing.T) {
+	fps := []*FileFingerprint{
+		{Path: "a.txt", Size: 1, MD5: "aaa", Lines: []string{"x"}},
+		{Path: "b.txt", Size: 2, MD5: "bbb", Lines: []string{"y", "z"}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteWFP(&buf, fps))
+
+	assert.Equal(t, "file=aaa,1,a.txt\n1=x\nfile=bbb,2,b.txt\n1=y\n2=z\n", buf.String())
+}
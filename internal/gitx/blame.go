@@ -0,0 +1,98 @@
+package gitx
+
+import (
+	"fmt"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// BlameLine describes the commit that last touched a single line of a file.
+type BlameLine struct {
+	LineNum int
+	Author  string
+	Email   string
+	Commit  string
+	Date    string
+	Summary string
+}
+
+// Blamer is implemented by anything that can attribute lines of a file to commits, so
+// scanner tests can stub it out instead of paying for a real blame pass.
+type Blamer interface {
+	Blame(repoPath, path, rev string) ([]BlameLine, error)
+}
+
+// GitBlamer is the default Blamer backed by go-git's blame subsystem.
+type GitBlamer struct{}
+
+// Blame runs go-git blame against path at rev (HEAD if rev is empty) and returns one
+// BlameLine per line of the file, in order.
+func (GitBlamer) Blame(repoPath, path, rev string) ([]BlameLine, error) {
+	return Blame(repoPath, path, rev)
+}
+
+// Blame opens repoPath, resolves rev (HEAD if empty), and runs go-git's blame for path,
+// returning per-line author/commit attribution.
+func Blame(repoPath, path, rev string) ([]BlameLine, error) {
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var hash plumbing.Hash
+	if rev == "" {
+		rev = "HEAD"
+	}
+	resolved, err := repo.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		return nil, err
+	}
+	hash = *resolved
+
+	commit, err := repo.CommitObject(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := git.Blame(commit, path)
+	if err != nil {
+		return nil, fmt.Errorf("blame %s@%s: %w", path, rev, err)
+	}
+
+	// Cache commit lookups: the same commit usually owns many consecutive lines.
+	emails := make(map[plumbing.Hash]string)
+	summaries := make(map[plumbing.Hash]string)
+
+	lines := make([]BlameLine, len(result.Lines))
+	for i, l := range result.Lines {
+		email, ok := emails[l.Hash]
+		if !ok {
+			if c, err := repo.CommitObject(l.Hash); err == nil {
+				email = c.Author.Email
+				summaries[l.Hash] = firstLine(c.Message)
+			}
+			emails[l.Hash] = email
+		}
+
+		lines[i] = BlameLine{
+			LineNum: i + 1,
+			Author:  l.Author,
+			Email:   email,
+			Date:    l.Date.Format("2006-01-02T15:04:05Z07:00"),
+			Commit:  l.Hash.String(),
+			Summary: summaries[l.Hash],
+		}
+	}
+
+	return lines, nil
+}
+
+func firstLine(s string) string {
+	for i, c := range s {
+		if c == '\n' {
+			return s[:i]
+		}
+	}
+	return s
+}
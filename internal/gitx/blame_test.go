@@ -0,0 +1,57 @@
+package gitx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlame(t *testing.T) {
+	dir, commits := makeRepo(t,
+		repoOp{commitMsg: "initial", files: map[string]string{"a.txt": "line one\nline two\n"}},
+		repoOp{commitMsg: "second", files: map[string]string{"a.txt": "line one\nline two\nline three\n"}},
+	)
+
+	lines, err := Blame(dir, "a.txt", "")
+	require.NoError(t, err)
+	require.Len(t, lines, 3)
+
+	assert.Equal(t, commits["initial"].String(), lines[0].Commit)
+	assert.Equal(t, commits["initial"].String(), lines[1].Commit)
+	assert.Equal(t, commits["second"].String(), lines[2].Commit)
+	assert.Equal(t, "test@example.com", lines[0].Email)
+	assert.Equal(t, 1, lines[0].LineNum)
+}
+
+func TestBlame_AtRevision(t *testing.T) {
+	dir, commits := makeRepo(t,
+		repoOp{commitMsg: "initial", files: map[string]string{"a.txt": "line one\n"}},
+		repoOp{commitMsg: "second", files: map[string]string{"a.txt": "line one\nline two\n"}},
+	)
+
+	lines, err := Blame(dir, "a.txt", commits["initial"].String())
+	require.NoError(t, err)
+	require.Len(t, lines, 1)
+	assert.Equal(t, commits["initial"].String(), lines[0].Commit)
+}
+
+func TestBlame_NonExistentRepo(t *testing.T) {
+	_, err := Blame("/nonexistent/repo", "a.txt", "")
+	assert.Error(t, err)
+}
+
+func TestBlame_NonExistentPath(t *testing.T) {
+	dir, _ := makeRepo(t, repoOp{commitMsg: "initial", files: map[string]string{"a.txt": "content"}})
+
+	_, err := Blame(dir, "missing.txt", "")
+	assert.Error(t, err)
+}
+
+func TestGitBlamer_DelegatesToBlame(t *testing.T) {
+	dir, _ := makeRepo(t, repoOp{commitMsg: "initial", files: map[string]string{"a.txt": "content\n"}})
+
+	lines, err := GitBlamer{}.Blame(dir, "a.txt", "")
+	require.NoError(t, err)
+	assert.Len(t, lines, 1)
+}
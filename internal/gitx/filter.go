@@ -0,0 +1,213 @@
+package gitx
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+)
+
+// FilterOptions controls which files Matcher (and the ChangedFiles/FilesInRange/getAllFiles
+// convenience entry points, which apply DefaultFilterOptions) exclude from a file list.
+type FilterOptions struct {
+	RespectGitignore bool
+
+	// RespectGitattributesLinguistGenerated drops paths a repo's .gitattributes marks
+	// linguist-generated=true or linguist-vendored=true - the same signal GitHub's Linguist
+	// uses to exclude generated/vendored code from language stats and diffs.
+	RespectGitattributesLinguistGenerated bool
+
+	// ExtraExcludes are additional gitignore-style glob patterns applied on top of whatever
+	// RespectGitignore loads.
+	ExtraExcludes []string
+}
+
+// DefaultFilterOptions is applied by ChangedFiles, FilesInRange, and getAllFiles: respect both
+// .gitignore and linguist-generated/vendored markers, with no caller-supplied extra excludes.
+var DefaultFilterOptions = FilterOptions{
+	RespectGitignore:                      true,
+	RespectGitattributesLinguistGenerated: true,
+}
+
+// linguistAttrs records the linguist-generated/linguist-vendored attributes .gitattributes
+// assigns to a path.
+type linguistAttrs struct {
+	generated bool
+	vendored  bool
+}
+
+// Matcher decides whether a repo-relative path should be excluded from a scan. It's built from
+// a repo's .gitignore chain (root, every subdirectory, $GIT_DIR/info/exclude, the user's global
+// excludesfile) plus .gitattributes linguist-generated/linguist-vendored markers, so the same
+// exclusion semantics can back both gitx's git-history file lists and scanner's plain-directory
+// walk.
+type Matcher struct {
+	ignore gitignore.Matcher
+	attrs  map[string]linguistAttrs
+}
+
+// NewMatcher builds a Matcher for the repo rooted at root. It always succeeds: a root with no
+// .gitignore/.gitattributes files simply yields a Matcher that excludes nothing beyond
+// ExtraExcludes.
+func NewMatcher(root string, opts FilterOptions) *Matcher {
+	var patterns []gitignore.Pattern
+
+	if opts.RespectGitignore {
+		patterns = append(patterns, readGitignoreChain(root)...)
+	}
+	for _, pattern := range opts.ExtraExcludes {
+		patterns = append(patterns, gitignore.ParsePattern(pattern, nil))
+	}
+
+	var attrs map[string]linguistAttrs
+	if opts.RespectGitattributesLinguistGenerated {
+		attrs = readLinguistAttributes(root)
+	}
+
+	return &Matcher{
+		ignore: gitignore.NewMatcher(patterns),
+		attrs:  attrs,
+	}
+}
+
+// SkipDir reports whether relPath (relative to root, split on the OS path separator) should be
+// pruned entirely, e.g. via filepath.SkipDir.
+func (m *Matcher) SkipDir(relPath string) bool {
+	return m.ignore.Match(strings.Split(relPath, string(filepath.Separator)), true)
+}
+
+// SkipFile reports whether relPath should be excluded, either because it matches an ignore
+// pattern or because .gitattributes marks it linguist-generated or linguist-vendored.
+func (m *Matcher) SkipFile(relPath string) bool {
+	if m.ignore.Match(strings.Split(relPath, string(filepath.Separator)), false) {
+		return true
+	}
+	if attrs, ok := m.attrs[filepath.ToSlash(relPath)]; ok && (attrs.generated || attrs.vendored) {
+		return true
+	}
+	return false
+}
+
+// filterPaths drops every path in paths that matcher excludes as a file.
+func filterPaths(matcher *Matcher, paths []string) []string {
+	if matcher == nil {
+		return paths
+	}
+	filtered := paths[:0]
+	for _, p := range paths {
+		if !matcher.SkipFile(p) {
+			filtered = append(filtered, p)
+		}
+	}
+	return filtered
+}
+
+// readGitignoreChain collects patterns from root/.gitignore, every subdirectory's .gitignore,
+// root/.git/info/exclude, and the user's global excludes file.
+func readGitignoreChain(root string) []gitignore.Pattern {
+	var patterns []gitignore.Pattern
+
+	patterns = append(patterns, readPatternFile(globalExcludesPath(), nil)...)
+	patterns = append(patterns, readPatternFile(filepath.Join(root, ".git", "info", "exclude"), nil)...)
+
+	_ = filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Base(path) != ".gitignore" {
+			return nil
+		}
+		rel, relErr := filepath.Rel(root, filepath.Dir(path))
+		var domain []string
+		if relErr == nil && rel != "." {
+			domain = strings.Split(rel, string(filepath.Separator))
+		}
+		patterns = append(patterns, readPatternFile(path, domain)...)
+		return nil
+	})
+
+	return patterns
+}
+
+// globalExcludesPath returns the user's global gitignore file, defaulting to
+// $XDG_CONFIG_HOME/git/ignore (or ~/.config/git/ignore) since reading core.excludesfile out of
+// git config would pull in a config parser this package doesn't otherwise need.
+func globalExcludesPath() string {
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		return filepath.Join(xdg, "git", "ignore")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "git", "ignore")
+}
+
+func readPatternFile(path string, domain []string) []gitignore.Pattern {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var patterns []gitignore.Pattern
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := sc.Text()
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		patterns = append(patterns, gitignore.ParsePattern(line, domain))
+	}
+	return patterns
+}
+
+// readLinguistAttributes parses every .gitattributes file under root into a flat map, keyed by
+// the file path (relative to root, slash-separated) it applies to, recording whether that path
+// is marked linguist-generated=true or linguist-vendored=true.
+func readLinguistAttributes(root string) map[string]linguistAttrs {
+	result := make(map[string]linguistAttrs)
+
+	_ = filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Base(path) != ".gitattributes" {
+			return nil
+		}
+
+		dir := filepath.Dir(path)
+		f, openErr := os.Open(path)
+		if openErr != nil {
+			return nil
+		}
+		defer f.Close()
+
+		sc := bufio.NewScanner(f)
+		for sc.Scan() {
+			fields := strings.Fields(sc.Text())
+			if len(fields) < 2 {
+				continue
+			}
+			pattern := fields[0]
+			attrs := linguistAttrs{}
+			for _, attr := range fields[1:] {
+				switch attr {
+				case "linguist-generated", "linguist-generated=true":
+					attrs.generated = true
+				case "linguist-vendored", "linguist-vendored=true":
+					attrs.vendored = true
+				}
+			}
+			if !attrs.generated && !attrs.vendored {
+				continue
+			}
+
+			matches, _ := filepath.Glob(filepath.Join(dir, pattern))
+			for _, m := range matches {
+				if rel, err := filepath.Rel(root, m); err == nil {
+					result[filepath.ToSlash(rel)] = attrs
+				}
+			}
+		}
+		return nil
+	})
+
+	return result
+}
@@ -2,15 +2,120 @@ package gitx
 
 import (
 	"errors"
+	"io"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
 )
 
 // ErrInvalidRange is returned when the from commit is after the to commit
 var ErrInvalidRange = errors.New("from commit is after to commit")
 
+// CommitBlob pairs a blob with the commit and path it was found at while walking history.
+type CommitBlob struct {
+	Commit *object.Commit
+	Path   string
+	Blob   *object.Blob
+}
+
+// WalkHistory walks every commit reachable from rev (HEAD if rev is empty), oldest-parent
+// links included, and invokes visit once per (commit, file) pair in that commit's tree.
+// Iteration stops, and the error is returned, if visit or the underlying git calls fail.
+// since, when non-empty, is resolved to a commit and the walk stops once it is reached.
+func WalkHistory(repoPath, rev, since string, maxDepth int, visit func(CommitBlob) error) error {
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return err
+	}
+
+	var startHash plumbing.Hash
+	if rev != "" {
+		h, err := repo.ResolveRevision(plumbing.Revision(rev))
+		if err != nil {
+			return err
+		}
+		startHash = *h
+	} else {
+		head, err := repo.Head()
+		if err != nil {
+			return err
+		}
+		startHash = head.Hash()
+	}
+
+	start, err := repo.CommitObject(startHash)
+	if err != nil {
+		return err
+	}
+
+	var sinceHash plumbing.Hash
+	if since != "" {
+		h, err := repo.ResolveRevision(plumbing.Revision(since))
+		if err != nil {
+			return err
+		}
+		sinceHash = *h
+	}
+
+	iter := object.NewCommitPreorderIter(start, nil, nil)
+	depth := 0
+	return iter.ForEach(func(c *object.Commit) error {
+		if since != "" && c.Hash == sinceHash {
+			return storer.ErrStop
+		}
+		if maxDepth > 0 && depth >= maxDepth {
+			return storer.ErrStop
+		}
+		depth++
+
+		tree, err := c.Tree()
+		if err != nil {
+			return err
+		}
+
+		return tree.Files().ForEach(func(f *object.File) error {
+			return visit(CommitBlob{Commit: c, Path: f.Name, Blob: &f.Blob})
+		})
+	})
+}
+
+// IntroducedIn walks the parents of commit, following the given path, and returns the hash
+// of the earliest ancestor commit whose blob for path still produces an identical match
+// (as reported by matches). Only first parents are followed. It returns the hash of the last
+// commit on that chain that still reproduces the match (commit itself if its first parent does
+// not), stopping once a first parent lacks path or no longer matches, or at a root commit.
+func IntroducedIn(commit *object.Commit, path string, matches func(io.Reader) (bool, error)) (plumbing.Hash, error) {
+	current := commit
+	for {
+		parents := current.Parents()
+		parent, err := parents.Next()
+		if err != nil {
+			// No more parents (root commit): current is the introducing commit.
+			return current.Hash, nil
+		}
+
+		file, err := parent.File(path)
+		if err != nil {
+			// Path didn't exist in the parent: current introduced it.
+			return current.Hash, nil
+		}
+
+		r, err := file.Reader()
+		if err != nil {
+			return current.Hash, nil
+		}
+		ok, err := matches(r)
+		r.Close()
+		if err != nil || !ok {
+			return current.Hash, nil
+		}
+
+		current = parent
+	}
+}
+
 // ChangedFiles returns a list of files that have changed since the given revision
 func ChangedFiles(repoPath string, since string) ([]string, error) {
 	repo, err := git.PlainOpen(repoPath)
@@ -43,9 +148,11 @@ func ChangedFiles(repoPath string, since string) ([]string, error) {
 		return nil, err
 	}
 
+	matcher := NewMatcher(repoPath, DefaultFilterOptions)
+
 	// If no since commit specified, return all files
 	if sinceCommit == nil {
-		return getAllFiles(repo, currentCommit)
+		return getAllFiles(repo, currentCommit, matcher)
 	}
 
 	// Get the diff between commits
@@ -60,7 +167,7 @@ func ChangedFiles(repoPath string, since string) ([]string, error) {
 		files = append(files, fileStat.Name)
 	}
 
-	return files, nil
+	return filterPaths(matcher, files), nil
 }
 
 // FilesInRange returns all files in the given commit range
@@ -122,12 +229,16 @@ func FilesInRange(repoPath, from, to string) ([]string, error) {
 
 		return err
 	})
+	if err != nil {
+		return nil, err
+	}
 
-	return files, err
+	return filterPaths(NewMatcher(repoPath, DefaultFilterOptions), files), nil
 }
 
-// Helper function to get all files in a commit
-func getAllFiles(repo *git.Repository, commit *object.Commit) ([]string, error) {
+// Helper function to get all files in a commit. matcher, when non-nil, drops files it
+// considers ignored or linguist-generated/vendored.
+func getAllFiles(repo *git.Repository, commit *object.Commit, matcher *Matcher) ([]string, error) {
 	var files []string
 	tree, err := commit.Tree()
 	if err != nil {
@@ -138,6 +249,9 @@ func getAllFiles(repo *git.Repository, commit *object.Commit) ([]string, error)
 		files = append(files, f.Name)
 		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
 
-	return files, err
+	return filterPaths(matcher, files), nil
 }
@@ -1,8 +1,11 @@
 package gitx
 
 import (
+	"errors"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -363,3 +366,90 @@ func TestFilesInRange_NonExistentRepo(t *testing.T) {
 		t.Error("FilesInRange() expected error for non-existent repo")
 	}
 }
+
+func TestWalkHistory(t *testing.T) {
+	dir, _ := makeRepo(t,
+		repoOp{commitMsg: "initial", files: map[string]string{"a.txt": "a"}},
+		repoOp{commitMsg: "second", files: map[string]string{"b.txt": "b"}},
+		repoOp{commitMsg: "third", files: map[string]string{"a.txt": "a2"}},
+	)
+
+	var visited []string
+	err := WalkHistory(dir, "", "", 0, func(cb CommitBlob) error {
+		visited = append(visited, cb.Commit.Message+":"+cb.Path)
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, visited, "initial:a.txt")
+	assert.Contains(t, visited, "second:a.txt")
+	assert.Contains(t, visited, "second:b.txt")
+	assert.Contains(t, visited, "third:a.txt")
+
+	t.Run("StopsAtSince", func(t *testing.T) {
+		var visited []string
+		err := WalkHistory(dir, "", "initial", 0, func(cb CommitBlob) error {
+			visited = append(visited, cb.Commit.Message)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.NotContains(t, visited, "initial")
+		assert.Contains(t, visited, "third")
+	})
+
+	t.Run("RespectsMaxDepth", func(t *testing.T) {
+		var visited []string
+		err := WalkHistory(dir, "", "", 1, func(cb CommitBlob) error {
+			visited = append(visited, cb.Commit.Message)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.NotContains(t, visited, "initial")
+		assert.NotContains(t, visited, "second")
+	})
+
+	t.Run("VisitErrorPropagates", func(t *testing.T) {
+		wantErr := errors.New("visit failed")
+		err := WalkHistory(dir, "", "", 0, func(cb CommitBlob) error {
+			return wantErr
+		})
+		assert.ErrorIs(t, err, wantErr)
+	})
+
+	t.Run("NonExistentRepo", func(t *testing.T) {
+		err := WalkHistory("/nonexistent/repo", "", "", 0, func(cb CommitBlob) error { return nil })
+		assert.Error(t, err)
+	})
+}
+
+func TestIntroducedIn(t *testing.T) {
+	dir, commits := makeRepo(t,
+		repoOp{commitMsg: "initial", files: map[string]string{"secret.txt": "password=hunter2"}},
+		repoOp{commitMsg: "unrelated", files: map[string]string{"other.txt": "noise"}},
+		repoOp{commitMsg: "rotated", files: map[string]string{"secret.txt": "password=rotated"}},
+	)
+
+	repo, err := git.PlainOpen(dir)
+	require.NoError(t, err)
+
+	matches := func(needle string) func(io.Reader) (bool, error) {
+		return func(r io.Reader) (bool, error) {
+			content, err := io.ReadAll(r)
+			if err != nil {
+				return false, err
+			}
+			return strings.Contains(string(content), needle), nil
+		}
+	}
+
+	rotated, err := repo.CommitObject(commits["rotated"])
+	require.NoError(t, err)
+
+	introduced, err := IntroducedIn(rotated, "secret.txt", matches("hunter2"))
+	require.NoError(t, err)
+	assert.Equal(t, commits["initial"], introduced)
+
+	introduced, err = IntroducedIn(rotated, "secret.txt", matches("rotated"))
+	require.NoError(t, err)
+	assert.Equal(t, commits["rotated"], introduced)
+}
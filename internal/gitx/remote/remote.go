@@ -0,0 +1,158 @@
+// Package remote lets SentryScan scan a remote repository without an explicit local
+// checkout. It clones into a scratch directory and, once go-git's client exposes a way to
+// request server-side blob filtering (e.g. "blob:none", "blob:limit=<n>"), will negotiate
+// the smallest fetch the server supports; until then every fetch is a full clone.
+package remote
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+)
+
+// PathChange describes a single path that differs between two commits.
+type PathChange struct {
+	Path   string
+	OldOID plumbing.Hash
+	NewOID plumbing.Hash
+}
+
+// AuthOptions configures how NewPartialFetcher authenticates against url, so CI can point
+// SentryScan at a private repository without a pre-existing local checkout.
+type AuthOptions struct {
+	// SSHKeyPath, if set, authenticates over SSH using the given private key file.
+	SSHKeyPath     string
+	SSHKeyPassword string
+
+	// Token authenticates over HTTP(S) as a bearer/PAT-style credential, covering
+	// GitHub/GitLab/Bitbucket's "any non-empty username" convention for token auth.
+	Token string
+}
+
+// resolveAuth builds a go-git transport.AuthMethod from opts, preferring an SSH key when one
+// is given and falling back to HTTP token auth otherwise. It returns a nil AuthMethod (public,
+// unauthenticated access) when neither is configured.
+func resolveAuth(opts AuthOptions) (transport.AuthMethod, error) {
+	if opts.SSHKeyPath != "" {
+		auth, err := ssh.NewPublicKeysFromFile("git", opts.SSHKeyPath, opts.SSHKeyPassword)
+		if err != nil {
+			return nil, fmt.Errorf("load ssh key %s: %w", opts.SSHKeyPath, err)
+		}
+		return auth, nil
+	}
+	if opts.Token != "" {
+		return &http.BasicAuth{Username: "x-access-token", Password: opts.Token}, nil
+	}
+	return nil, nil
+}
+
+// PartialFetcher fetches a remote repository into a scratch clone so the rest of gitx's
+// git-history helpers can run against it exactly as they would against a pre-existing local
+// checkout. Today every fetch is a full clone: go-git's public CloneOptions has no knob to
+// request server-side blob filtering even when the server advertises the "filter"
+// capability, so there is currently no way to thread a "blob:none"/"blob:limit=<n>" request
+// through go-git's clone path. FullClone always reports true until that support lands
+// upstream; filter is kept on the struct so a future go-git release can slot in here without
+// touching call sites.
+type PartialFetcher struct {
+	url    string
+	filter string
+	dir    string
+	repo   *git.Repository
+}
+
+// NewPartialFetcher clones url into a temporary directory, authenticating with auth when
+// given (SSH key or HTTP token, letting CI point SentryScan at a private URL without
+// pre-cloning). filter is a partial-clone filter-spec hint (e.g. "blob:none",
+// "blob:limit=<n>"); see the PartialFetcher doc comment for why it isn't honored yet.
+func NewPartialFetcher(url, filter string, auth AuthOptions) (*PartialFetcher, error) {
+	dir, err := os.MkdirTemp("", "sentryscan-remote-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scratch dir: %w", err)
+	}
+
+	authMethod, err := resolveAuth(auth)
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+
+	repo, err := git.PlainClone(dir, false, &git.CloneOptions{URL: url, Auth: authMethod})
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("clone %s: %w", url, err)
+	}
+
+	return &PartialFetcher{url: url, filter: filter, dir: dir, repo: repo}, nil
+}
+
+// Close removes the scratch clone.
+func (p *PartialFetcher) Close() error {
+	return os.RemoveAll(p.dir)
+}
+
+// FullClone reports whether the fetch was a full, unfiltered clone. It always returns true
+// today; see the PartialFetcher doc comment.
+func (p *PartialFetcher) FullClone() bool {
+	return true
+}
+
+// ListChangedPaths enumerates the paths that differ between from and to (both revisions
+// resolvable by go-git, e.g. branch names, tags, or commit SHAs).
+func (p *PartialFetcher) ListChangedPaths(from, to string) ([]PathChange, error) {
+	fromHash, err := p.repo.ResolveRevision(plumbing.Revision(from))
+	if err != nil {
+		return nil, fmt.Errorf("resolve %q: %w", from, err)
+	}
+	toHash, err := p.repo.ResolveRevision(plumbing.Revision(to))
+	if err != nil {
+		return nil, fmt.Errorf("resolve %q: %w", to, err)
+	}
+
+	fromCommit, err := p.repo.CommitObject(*fromHash)
+	if err != nil {
+		return nil, err
+	}
+	toCommit, err := p.repo.CommitObject(*toHash)
+	if err != nil {
+		return nil, err
+	}
+
+	patch, err := fromCommit.Patch(toCommit)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []PathChange
+	for _, fp := range patch.FilePatches() {
+		fromFile, toFile := fp.Files()
+		change := PathChange{}
+		if fromFile != nil {
+			change.Path = fromFile.Path()
+			change.OldOID = fromFile.Hash()
+		}
+		if toFile != nil {
+			change.Path = toFile.Path()
+			change.NewOID = toFile.Hash()
+		}
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+// OpenBlob fetches (or, after a full clone, simply opens) the blob for oid.
+func (p *PartialFetcher) OpenBlob(oid plumbing.Hash) (io.ReadCloser, error) {
+	blob, err := object.GetBlob(p.repo.Storer, oid)
+	if err != nil {
+		return nil, fmt.Errorf("open blob %s: %w", oid, err)
+	}
+	return blob.Reader()
+}
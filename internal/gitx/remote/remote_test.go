@@ -0,0 +1,89 @@
+package remote
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// makeSourceRepo creates a local repository with two commits, "README.md" being added then
+// changed, so tests can exercise ListChangedPaths/OpenBlob against a real object graph.
+func makeSourceRepo(t *testing.T) string {
+	t.Helper()
+
+	dir, err := os.MkdirTemp("", "remote-test-src-*")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	repo, err := git.PlainInit(dir, false)
+	require.NoError(t, err)
+
+	wt, err := repo.Worktree()
+	require.NoError(t, err)
+
+	sig := &object.Signature{Name: "Test", Email: "test@example.com"}
+
+	readme := filepath.Join(dir, "README.md")
+	require.NoError(t, os.WriteFile(readme, []byte("first\n"), 0644))
+	_, err = wt.Add("README.md")
+	require.NoError(t, err)
+	_, err = wt.Commit("first", &git.CommitOptions{Author: sig})
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(readme, []byte("second\n"), 0644))
+	_, err = wt.Add("README.md")
+	require.NoError(t, err)
+	_, err = wt.Commit("second", &git.CommitOptions{Author: sig})
+	require.NoError(t, err)
+
+	return dir
+}
+
+func TestNewPartialFetcher_FallsBackToFullClone(t *testing.T) {
+	src := makeSourceRepo(t)
+
+	fetcher, err := NewPartialFetcher(src, "blob:none", AuthOptions{})
+	require.NoError(t, err)
+	defer fetcher.Close()
+
+	assert.True(t, fetcher.FullClone(), "go-git has no filtered-clone knob, so every fetch is full today")
+}
+
+func TestPartialFetcher_ListChangedPathsAndOpenBlob(t *testing.T) {
+	src := makeSourceRepo(t)
+
+	fetcher, err := NewPartialFetcher(src, "", AuthOptions{})
+	require.NoError(t, err)
+	defer fetcher.Close()
+
+	changes, err := fetcher.ListChangedPaths("HEAD~1", "HEAD")
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "README.md", changes[0].Path)
+
+	r, err := fetcher.OpenBlob(changes[0].NewOID)
+	require.NoError(t, err)
+	defer r.Close()
+
+	content, err := os.ReadFile(filepath.Join(fetcher.dir, "README.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "second\n", string(content))
+}
+
+func TestNewPartialFetcher_InvalidURL(t *testing.T) {
+	_, err := NewPartialFetcher(filepath.Join(t.TempDir(), "does-not-exist"), "", AuthOptions{})
+	assert.Error(t, err)
+}
+
+func TestNewPartialFetcher_BadSSHKey(t *testing.T) {
+	src := makeSourceRepo(t)
+
+	_, err := NewPartialFetcher(src, "", AuthOptions{SSHKeyPath: filepath.Join(t.TempDir(), "missing-key")})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ssh key")
+}
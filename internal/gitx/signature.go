@@ -0,0 +1,60 @@
+package gitx
+
+import (
+	"errors"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	pgperrors "github.com/ProtonMail/go-crypto/openpgp/errors"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// VerifyResult classifies the outcome of verifying a single commit's signature.
+type VerifyResult string
+
+const (
+	Unsigned   VerifyResult = "unsigned"
+	GoodSig    VerifyResult = "good_signature"
+	BadSig     VerifyResult = "bad_signature"
+	UnknownKey VerifyResult = "unknown_signer"
+)
+
+// VerifyCommit checks the PGP signature of the commit at hash against keyring (an
+// armored, possibly multi-key, OpenPGP keyring). An empty keyring still distinguishes
+// Unsigned from signed-but-unverifiable (reported as UnknownKey). The returned entity is the
+// signer's key when result is GoodSig, allowing callers to check it against an allowed-signer
+// fingerprint or key ID in addition to the commit's author email; it is nil otherwise.
+func VerifyCommit(repo *git.Repository, hash plumbing.Hash, keyring string) (VerifyResult, *openpgp.Entity, error) {
+	commit, err := repo.CommitObject(hash)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if commit.PGPSignature == "" {
+		return Unsigned, nil, nil
+	}
+
+	if keyring == "" {
+		return UnknownKey, nil, nil
+	}
+
+	entity, err := commit.Verify(keyring)
+	if err != nil {
+		if errors.Is(err, pgperrors.ErrUnknownIssuer) {
+			return UnknownKey, nil, nil
+		}
+		return BadSig, nil, nil
+	}
+
+	return GoodSig, entity, nil
+}
+
+// VerifyCommitAt opens repoPath and verifies the commit at hash, for callers (like the
+// scanner's history walker) that only have a path and a hash in hand.
+func VerifyCommitAt(repoPath string, hash plumbing.Hash, keyring string) (VerifyResult, *openpgp.Entity, error) {
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return "", nil, err
+	}
+	return VerifyCommit(repo, hash, keyring)
+}
@@ -0,0 +1,36 @@
+package gitx
+
+import (
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyCommit_Unsigned(t *testing.T) {
+	dir, commits := makeRepo(t, repoOp{commitMsg: "initial", files: map[string]string{"a.txt": "content"}})
+
+	repo, err := git.PlainOpen(dir)
+	require.NoError(t, err)
+
+	result, entity, err := VerifyCommit(repo, commits["initial"], "")
+	require.NoError(t, err)
+	assert.Equal(t, Unsigned, result)
+	assert.Nil(t, entity)
+}
+
+func TestVerifyCommitAt_Unsigned(t *testing.T) {
+	dir, commits := makeRepo(t, repoOp{commitMsg: "initial", files: map[string]string{"a.txt": "content"}})
+
+	result, entity, err := VerifyCommitAt(dir, commits["initial"], "")
+	require.NoError(t, err)
+	assert.Equal(t, Unsigned, result)
+	assert.Nil(t, entity)
+}
+
+func TestVerifyCommitAt_NonExistentRepo(t *testing.T) {
+	_, _, err := VerifyCommitAt("/nonexistent/repo", plumbing.ZeroHash, "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,147 @@
+package image
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/google/go-containerregistry/pkg/crane"
+	ggcrv1 "github.com/google/go-containerregistry/pkg/v1"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/ejagojo/SentryScan/internal/scanner"
+)
+
+// defaultPlatform selects linux/amd64 out of a multi-arch OCI index when --platform isn't set.
+const defaultPlatform = "linux/amd64"
+
+// imageHistoryEntry mirrors the part of an OCI/Docker image config's History array needed to
+// annotate a layer-diff finding with the Dockerfile instruction that produced its layer.
+// Instructions like ENV or LABEL don't add a layer, so EmptyLayer entries are skipped when
+// pairing history entries up with Layers.
+type imageHistoryEntry struct {
+	CreatedBy  string `json:"created_by"`
+	EmptyLayer bool   `json:"empty_layer,omitempty"`
+}
+
+// imageConfig is the subset of an image config JSON blob that layer-diff scanning needs.
+type imageConfig struct {
+	History []imageHistoryEntry `json:"history"`
+}
+
+// fetchManifest resolves imageRef to a single-platform manifest and its image config, selecting
+// platform out of a multi-arch OCI index if present. A legacy Docker schema v1 manifest has no
+// content-addressed layer digests to diff: it unmarshals into the OCI v2-shaped struct with no
+// Layers (its fsLayers field is not read), so it comes back as a manifest with no layers rather
+// than an error. Bytes that are not valid JSON come back as an empty manifest.
+func fetchManifest(imageRef, platform string) (*ocispec.Manifest, *imageConfig, error) {
+	if platform == "" {
+		platform = defaultPlatform
+	}
+	p, err := ggcrv1.ParsePlatform(platform)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid platform %q: %w", platform, err)
+	}
+
+	raw, err := crane.Manifest(imageRef, crane.WithPlatform(p))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch manifest for %s: %w", imageRef, err)
+	}
+
+	var manifest ocispec.Manifest
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return &ocispec.Manifest{}, &imageConfig{}, nil
+	}
+
+	cfg := &imageConfig{}
+	if cfgRaw, cfgErr := crane.Config(imageRef, crane.WithPlatform(p)); cfgErr == nil {
+		json.Unmarshal(cfgRaw, cfg)
+	}
+
+	return &manifest, cfg, nil
+}
+
+// diffLayers returns the layer descriptors present in target but not in base, matched by
+// digest. A nil base (or one with no Layers, e.g. a schema v1 fallback) contributes no layers,
+// so diffing against one is equivalent to treating every target layer as new.
+func diffLayers(target, base *ocispec.Manifest) ([]ocispec.Descriptor, error) {
+	if target == nil {
+		return nil, fmt.Errorf("target manifest is nil")
+	}
+
+	baseDigests := make(map[string]struct{})
+	if base != nil {
+		for _, l := range base.Layers {
+			baseDigests[string(l.Digest)] = struct{}{}
+		}
+	}
+
+	var diff []ocispec.Descriptor
+	for _, l := range target.Layers {
+		if _, exists := baseDigests[string(l.Digest)]; !exists {
+			diff = append(diff, l)
+		}
+	}
+
+	return diff, nil
+}
+
+// instructionForLayer returns the History entry that produced the nth non-empty layer in cfg,
+// or "" if the history is shorter than expected or cfg is nil.
+func instructionForLayer(cfg *imageConfig, layerIndex int) string {
+	if cfg == nil {
+		return ""
+	}
+
+	seen := 0
+	for _, h := range cfg.History {
+		if h.EmptyLayer {
+			continue
+		}
+		if seen == layerIndex {
+			return h.CreatedBy
+		}
+		seen++
+	}
+	return ""
+}
+
+// scanLayerTar pulls the layer at layerDigest from imageRef's repository and secret-scans every
+// regular file in its tarball, via the same SecretScanner.ScanReader path used for filesystem
+// scanning.
+func scanLayerTar(secretScanner scanner.Scanner, imageRef, layerDigest string) ([]scanner.Finding, error) {
+	layer, err := crane.PullLayer(imageRef + "@" + layerDigest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull layer %s: %w", layerDigest, err)
+	}
+
+	rc, err := layer.Uncompressed()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress layer %s: %w", layerDigest, err)
+	}
+	defer rc.Close()
+
+	var findings []scanner.Finding
+	tr := tar.NewReader(rc)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return findings, fmt.Errorf("failed to read layer %s tar entries: %w", layerDigest, err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		fileFindings, err := secretScanner.ScanReader(tr, scanner.SourceMeta{Path: hdr.Name})
+		if err != nil {
+			continue
+		}
+		findings = append(findings, fileFindings...)
+	}
+
+	return findings, nil
+}
@@ -0,0 +1,72 @@
+package image
+
+import (
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffLayers_NilTargetIsError(t *testing.T) {
+	_, err := diffLayers(nil, &ocispec.Manifest{})
+	assert.Error(t, err)
+}
+
+func TestDiffLayers_NilBaseTreatsEveryLayerAsNew(t *testing.T) {
+	target := &ocispec.Manifest{Layers: []ocispec.Descriptor{
+		{Digest: "sha256:a"},
+		{Digest: "sha256:b"},
+	}}
+
+	diff, err := diffLayers(target, nil)
+	require.NoError(t, err)
+	assert.Len(t, diff, 2)
+}
+
+func TestDiffLayers_OnlyNewLayersReturned(t *testing.T) {
+	base := &ocispec.Manifest{Layers: []ocispec.Descriptor{
+		{Digest: "sha256:a"},
+		{Digest: "sha256:b"},
+	}}
+	target := &ocispec.Manifest{Layers: []ocispec.Descriptor{
+		{Digest: "sha256:a"},
+		{Digest: "sha256:b"},
+		{Digest: "sha256:c"},
+	}}
+
+	diff, err := diffLayers(target, base)
+	require.NoError(t, err)
+	require.Len(t, diff, 1)
+	assert.Equal(t, digest.Digest("sha256:c"), diff[0].Digest)
+}
+
+func TestDiffLayers_IdenticalManifestsYieldNoDiff(t *testing.T) {
+	manifest := &ocispec.Manifest{Layers: []ocispec.Descriptor{{Digest: "sha256:a"}}}
+
+	diff, err := diffLayers(manifest, manifest)
+	require.NoError(t, err)
+	assert.Empty(t, diff)
+}
+
+func TestInstructionForLayer_NilConfig(t *testing.T) {
+	assert.Equal(t, "", instructionForLayer(nil, 0))
+}
+
+func TestInstructionForLayer_SkipsEmptyLayers(t *testing.T) {
+	cfg := &imageConfig{History: []imageHistoryEntry{
+		{CreatedBy: "ENV FOO=bar", EmptyLayer: true},
+		{CreatedBy: "COPY . .", EmptyLayer: false},
+		{CreatedBy: "LABEL x=y", EmptyLayer: true},
+		{CreatedBy: "RUN make build", EmptyLayer: false},
+	}}
+
+	assert.Equal(t, "COPY . .", instructionForLayer(cfg, 0))
+	assert.Equal(t, "RUN make build", instructionForLayer(cfg, 1))
+}
+
+func TestInstructionForLayer_OutOfRangeReturnsEmpty(t *testing.T) {
+	cfg := &imageConfig{History: []imageHistoryEntry{{CreatedBy: "COPY . ."}}}
+	assert.Equal(t, "", instructionForLayer(cfg, 5))
+}
@@ -21,6 +21,10 @@ const (
 type Scanner struct {
 	cacheDir string
 	client   *trivyScanner.Scanner
+
+	// Platform selects the image to scan out of a multi-arch OCI index, e.g. "linux/amd64".
+	// Defaults to defaultPlatform when empty.
+	Platform string
 }
 
 // NewScanner creates a new container image scanner
@@ -55,17 +59,27 @@ func (s *Scanner) Scan(ctx context.Context, imageRef string, compareRef string)
 		return nil, fmt.Errorf("failed to scan image: %w", err)
 	}
 
-	// If comparing with another image, get the diff
+	var findings []scanner.Finding
+
+	// If comparing with another image, get the CVE diff and additionally run a layer-aware
+	// secret scan over just the layers new to imageRef.
 	if compareRef != "" {
 		baseResults, err := s.client.ScanImage(ctx, compareRef)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan base image: %w", err)
 		}
 		results = diffResults(results, baseResults)
+
+		layerFindings, err := s.scanNewLayers(imageRef, compareRef)
+		if err != nil {
+			// Layer-diff scanning is additive on top of the Trivy CVE diff above: a registry
+			// that won't serve raw manifests shouldn't fail the whole image scan.
+			layerFindings = nil
+		}
+		findings = append(findings, layerFindings...)
 	}
 
 	// Convert to SentryScan findings
-	var findings []scanner.Finding
 	for _, result := range results {
 		if result.Severity == trivyTypes.SeverityCritical || result.Severity == trivyTypes.SeverityHigh {
 			findings = append(findings, scanner.Finding{
@@ -82,6 +96,52 @@ func (s *Scanner) Scan(ctx context.Context, imageRef string, compareRef string)
 	return findings, nil
 }
 
+// scanNewLayers fetches imageRef's and compareRef's manifests (resolving an OCI index to a
+// single platform, see Platform/defaultPlatform), diffs their layers by digest, and
+// secret-scans only the layers new to imageRef. Each finding is annotated with the layer's
+// digest and the Dockerfile instruction (from the image config's history) that produced it, so
+// users can see which line introduced a secret.
+func (s *Scanner) scanNewLayers(imageRef, compareRef string) ([]scanner.Finding, error) {
+	targetManifest, targetConfig, err := fetchManifest(imageRef, s.Platform)
+	if err != nil {
+		return nil, err
+	}
+	baseManifest, _, err := fetchManifest(compareRef, s.Platform)
+	if err != nil {
+		return nil, err
+	}
+
+	newLayers, err := diffLayers(targetManifest, baseManifest)
+	if err != nil {
+		return nil, err
+	}
+
+	layerIndex := make(map[string]int, len(targetManifest.Layers))
+	for i, l := range targetManifest.Layers {
+		layerIndex[string(l.Digest)] = i
+	}
+
+	secretScanner := scanner.NewScanner()
+
+	var findings []scanner.Finding
+	for _, layer := range newLayers {
+		digest := string(layer.Digest)
+		instruction := instructionForLayer(targetConfig, layerIndex[digest])
+
+		layerFindings, err := scanLayerTar(secretScanner, imageRef, digest)
+		if err != nil {
+			continue
+		}
+		for i := range layerFindings {
+			layerFindings[i].LayerDigest = digest
+			layerFindings[i].CreatedByInstruction = instruction
+		}
+		findings = append(findings, layerFindings...)
+	}
+
+	return findings, nil
+}
+
 // getCacheDir returns the path to the Trivy cache directory
 func getCacheDir() (string, error) {
 	cacheDir := os.Getenv("XDG_CACHE_HOME")
@@ -6,6 +6,7 @@ import (
 	"io"
 	"strings"
 
+	"github.com/ejagojo/SentryScan/internal/fingerprint"
 	"github.com/ejagojo/SentryScan/internal/scanner"
 	"github.com/jedib0t/go-pretty/v6/table"
 )
@@ -17,24 +18,29 @@ const (
 	OutputTypeConsole OutputType = "console"
 	OutputTypeJSON    OutputType = "json"
 	OutputTypeSARIF   OutputType = "sarif"
+	OutputTypeWFP     OutputType = "wfp"
 )
 
-// WriteFindings writes the findings to the specified output
-func WriteFindings(findings []scanner.Finding, outputType OutputType, w io.Writer) error {
+// WriteFindings writes the findings to the specified output. verbose only affects the console
+// writer, where it renders each finding's remediation text below its row.
+func WriteFindings(findings []scanner.Finding, outputType OutputType, w io.Writer, verbose bool) error {
 	switch outputType {
 	case OutputTypeConsole:
-		return writeConsole(findings, w)
+		return writeConsole(findings, w, verbose)
 	case OutputTypeJSON:
 		return writeJSON(findings, w)
 	case OutputTypeSARIF:
 		return writeSARIF(findings, w)
+	case OutputTypeWFP:
+		return writeWFP(findings, w)
 	default:
 		return fmt.Errorf("unsupported output type: %s", outputType)
 	}
 }
 
-// writeConsole writes findings in a human-readable table format
-func writeConsole(findings []scanner.Finding, w io.Writer) error {
+// writeConsole writes findings in a human-readable table format. In verbose mode, the
+// remediation text and URL (if any) for each finding are printed on the line below its row.
+func writeConsole(findings []scanner.Finding, w io.Writer, verbose bool) error {
 	t := table.NewWriter()
 	t.SetOutputMirror(w)
 	t.AppendHeader(table.Row{"Severity", "Rule", "File", "Line", "Description"})
@@ -50,6 +56,20 @@ func writeConsole(findings []scanner.Finding, w io.Writer) error {
 	}
 
 	t.Render()
+
+	if verbose {
+		for _, f := range findings {
+			if f.Remediation.Text == "" {
+				continue
+			}
+			fmt.Fprintf(w, "  %s: %s", f.RuleID, f.Remediation.Text)
+			if f.Remediation.URL != "" {
+				fmt.Fprintf(w, " (%s)", f.Remediation.URL)
+			}
+			fmt.Fprintln(w)
+		}
+	}
+
 	return nil
 }
 
@@ -85,6 +105,10 @@ func writeSARIF(findings []scanner.Finding, w io.Writer) error {
 	run := report["runs"].([]map[string]interface{})[0]
 	driver := run["tool"].(map[string]interface{})["driver"].(map[string]interface{})
 
+	if provenance := buildVersionControlProvenance(findings); len(provenance) > 0 {
+		run["versionControlProvenance"] = provenance
+	}
+
 	for _, f := range findings {
 		if !rules[f.RuleID] {
 			rule := map[string]interface{}{
@@ -106,6 +130,7 @@ func writeSARIF(findings []scanner.Finding, w io.Writer) error {
 		result := map[string]interface{}{
 			"ruleId":  f.RuleID,
 			"level":   mapSeverityToLevel(f.Severity),
+			"kind":    mapViolationToKind(f),
 			"message": map[string]interface{}{"text": f.Description},
 			"locations": []map[string]interface{}{
 				{
@@ -120,7 +145,13 @@ func writeSARIF(findings []scanner.Finding, w io.Writer) error {
 					},
 				},
 			},
+			"properties": buildFindingProperties(f),
+		}
+
+		if fix := buildFix(f); fix != nil {
+			result["fixes"] = []map[string]interface{}{fix}
 		}
+
 		run["results"] = append(run["results"].([]map[string]interface{}), result)
 	}
 
@@ -129,6 +160,112 @@ func writeSARIF(findings []scanner.Finding, w io.Writer) error {
 	return enc.Encode(report)
 }
 
+// writeWFP writes a .sentryscan.fingerprints.wfp-style report: the WFP fingerprint (see
+// internal/fingerprint) of each distinct file a finding was reported in, deduplicated so a file
+// with several findings is only fingerprinted once.
+func writeWFP(findings []scanner.Finding, w io.Writer) error {
+	seen := make(map[string]bool)
+	var fingerprints []*fingerprint.FileFingerprint
+
+	for _, f := range findings {
+		if seen[f.Path] {
+			continue
+		}
+		seen[f.Path] = true
+
+		fp, err := fingerprint.Compute(f.Path)
+		if err != nil {
+			return fmt.Errorf("failed to fingerprint %s: %w", f.Path, err)
+		}
+		fingerprints = append(fingerprints, fp)
+	}
+
+	return fingerprint.WriteWFP(w, fingerprints)
+}
+
+// buildVersionControlProvenance collects one SARIF versionControlDetails entry per distinct
+// commit referenced by findings produced by GitScanner mode or --blame (see
+// scanner.Finding.CommitSHA), so consumers can see which commits a run actually walked.
+func buildVersionControlProvenance(findings []scanner.Finding) []map[string]interface{} {
+	seen := make(map[string]bool)
+	var provenance []map[string]interface{}
+
+	for _, f := range findings {
+		if f.CommitSHA == "" || seen[f.CommitSHA] {
+			continue
+		}
+		seen[f.CommitSHA] = true
+
+		provenance = append(provenance, map[string]interface{}{
+			"revisionId": f.CommitSHA,
+			"properties": map[string]interface{}{
+				"author":      f.Author,
+				"authorEmail": f.AuthorEmail,
+				"committedAt": f.CommittedAt,
+				"parentSHA":   f.ParentSHA,
+			},
+		})
+	}
+
+	return provenance
+}
+
+// buildFindingProperties surfaces scanner.Finding's structured remediation data under SARIF's
+// free-form properties bag, for consumers that read properties rather than fixes[].
+func buildFindingProperties(f scanner.Finding) map[string]interface{} {
+	props := map[string]interface{}{
+		"probe": f.Probe,
+	}
+
+	if f.Remediation.Text != "" || f.Remediation.URL != "" {
+		props["remediation"] = map[string]interface{}{
+			"text":             f.Remediation.Text,
+			"url":              f.Remediation.URL,
+			"autoFixAvailable": f.Remediation.AutoFix != nil,
+		}
+	}
+
+	if f.Evidence != (scanner.Evidence{}) {
+		props["evidence"] = map[string]interface{}{
+			"snippet":      f.Evidence.Snippet,
+			"fingerprint":  f.Evidence.Fingerprint,
+			"entropyScore": f.Evidence.EntropyScore,
+			"confidence":   f.Evidence.Confidence,
+		}
+	}
+
+	return props
+}
+
+// buildFix returns a SARIF fix object for findings whose rule advertises an automated fix, or
+// nil otherwise. SentryScan doesn't yet generate replacement text, so the artifactChange
+// carries a description rather than an insertion/deletion - the intent is to give downstream
+// tools a documented location to apply one once auto-fix generation lands.
+func buildFix(f scanner.Finding) map[string]interface{} {
+	if f.Remediation.AutoFix == nil {
+		return nil
+	}
+
+	return map[string]interface{}{
+		"description": map[string]interface{}{"text": f.Remediation.AutoFix.Description},
+		"artifactChanges": []map[string]interface{}{
+			{
+				"artifactLocation": map[string]interface{}{"uri": f.Path},
+			},
+		},
+	}
+}
+
+// mapViolationToKind maps a finding's ViolationContext to SARIF's kind property: "fail" for a
+// policy-breaking violation, "informational" for an unclassified or non-violating finding (the
+// jfrog-cli-security vulnerabilities/violations split).
+func mapViolationToKind(f scanner.Finding) string {
+	if f.Violations != nil && f.Violations.Violation {
+		return "fail"
+	}
+	return "informational"
+}
+
 // mapSeverityToLevel maps our severity levels to SARIF levels
 func mapSeverityToLevel(severity string) string {
 	switch strings.ToLower(severity) {
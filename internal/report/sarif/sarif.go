@@ -0,0 +1,186 @@
+// Package sarif serializes []scanner.Finding into SARIF 2.1.0 (the OASIS static-analysis
+// interchange format GitHub code scanning and most IDE integrations consume), as a typed
+// alternative to internal/output's map[string]interface{}-based SARIF writer.
+package sarif
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/ejagojo/SentryScan/internal/scanner"
+)
+
+const (
+	schemaURI      = "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json"
+	toolName       = "SentryScan"
+	toolInfoURI    = "https://github.com/ejagojo/SentryScan"
+	sarifVersion   = "2.1.0"
+	fingerprintKey = "sentryscan/secretHash"
+)
+
+// Log is the top-level SARIF document.
+type Log struct {
+	Version string `json:"version"`
+	Schema  string `json:"$schema"`
+	Runs    []Run  `json:"runs"`
+}
+
+// Run is one SARIF run: the tool that produced it, plus every result from that run.
+type Run struct {
+	Tool    Tool     `json:"tool"`
+	Results []Result `json:"results"`
+}
+
+// Tool describes the analysis tool under the SARIF tool.driver shape.
+type Tool struct {
+	Driver Driver `json:"driver"`
+}
+
+// Driver carries the tool's identity and the full set of rules it's capable of reporting.
+type Driver struct {
+	Name           string                `json:"name"`
+	InformationURI string                `json:"informationUri"`
+	Rules          []ReportingDescriptor `json:"rules"`
+}
+
+// ReportingDescriptor is one entry in driver.rules: the static description of a rule,
+// independent of any particular match.
+type ReportingDescriptor struct {
+	ID                   string                  `json:"id"`
+	ShortDescription     Message                 `json:"shortDescription"`
+	DefaultConfiguration *ReportingConfiguration `json:"defaultConfiguration,omitempty"`
+	HelpURI              string                  `json:"helpUri,omitempty"`
+}
+
+// ReportingConfiguration holds a rule's default severity level.
+type ReportingConfiguration struct {
+	Level string `json:"level"`
+}
+
+// Message is SARIF's wrapper for free text.
+type Message struct {
+	Text string `json:"text"`
+}
+
+// Result is one finding: which rule fired, at what severity, where, and (via
+// PartialFingerprints) a stable key GitHub code scanning uses to match the same finding across
+// runs so it isn't re-flagged as new every scan.
+type Result struct {
+	RuleID              string            `json:"ruleId"`
+	Level               string            `json:"level"`
+	Message             Message           `json:"message"`
+	Locations           []Location        `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints,omitempty"`
+}
+
+// Location is a single place a result was found.
+type Location struct {
+	PhysicalLocation PhysicalLocation `json:"physicalLocation"`
+}
+
+// PhysicalLocation pairs an artifact (file) with a region (line/column) within it.
+type PhysicalLocation struct {
+	ArtifactLocation ArtifactLocation `json:"artifactLocation"`
+	Region           Region           `json:"region"`
+}
+
+// ArtifactLocation identifies the file a result was found in.
+type ArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// Region is a line/column span within an artifact. StartColumn is omitted (via omitempty) for
+// findings - git-history provenance checks, mostly - that have no column, since 0 isn't a valid
+// SARIF column.
+type Region struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// Build converts findings into a SARIF Log with a single run. Rules are deduplicated by RuleID
+// so a rule that fired a hundred times still appears once in driver.rules.
+func Build(findings []scanner.Finding) Log {
+	seen := make(map[string]bool)
+	var rules []ReportingDescriptor
+	var results []Result
+
+	for _, f := range findings {
+		if !seen[f.RuleID] {
+			seen[f.RuleID] = true
+			rules = append(rules, ReportingDescriptor{
+				ID:               f.RuleID,
+				ShortDescription: Message{Text: f.Description},
+				DefaultConfiguration: &ReportingConfiguration{
+					Level: mapSeverityToLevel(f.Severity),
+				},
+			})
+		}
+
+		results = append(results, buildResult(f))
+	}
+
+	return Log{
+		Version: sarifVersion,
+		Schema:  schemaURI,
+		Runs: []Run{
+			{
+				Tool: Tool{
+					Driver: Driver{
+						Name:           toolName,
+						InformationURI: toolInfoURI,
+						Rules:          rules,
+					},
+				},
+				Results: results,
+			},
+		},
+	}
+}
+
+// Marshal builds a SARIF Log from findings and renders it as indented JSON.
+func Marshal(findings []scanner.Finding) ([]byte, error) {
+	return json.MarshalIndent(Build(findings), "", "  ")
+}
+
+func buildResult(f scanner.Finding) Result {
+	result := Result{
+		RuleID:  f.RuleID,
+		Level:   mapSeverityToLevel(f.Severity),
+		Message: Message{Text: f.Description},
+		Locations: []Location{
+			{
+				PhysicalLocation: PhysicalLocation{
+					ArtifactLocation: ArtifactLocation{URI: f.Path},
+					Region: Region{
+						StartLine:   f.Line,
+						StartColumn: f.Column,
+					},
+				},
+			},
+		},
+	}
+
+	// Prefer the Evidence fingerprint (set for every rule-engine match) and fall back to the
+	// top-level Finding fingerprint, so a finding from any code path still gets a stable key.
+	if fp := f.Evidence.Fingerprint; fp != "" {
+		result.PartialFingerprints = map[string]string{fingerprintKey: fp}
+	} else if f.Fingerprint != "" {
+		result.PartialFingerprints = map[string]string{fingerprintKey: f.Fingerprint}
+	}
+
+	return result
+}
+
+// mapSeverityToLevel maps SentryScan's severity scale to SARIF's result/rule level enum.
+func mapSeverityToLevel(severity string) string {
+	switch strings.ToLower(severity) {
+	case "critical", "high":
+		return "error"
+	case "medium":
+		return "warning"
+	case "low":
+		return "note"
+	default:
+		return "warning"
+	}
+}
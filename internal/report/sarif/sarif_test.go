@@ -0,0 +1,56 @@
+package sarif
+
+import (
+	"testing"
+
+	"github.com/ejagojo/SentryScan/internal/scanner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuild_DedupesRulesAndCarriesFingerprint(t *testing.T) {
+	findings := []scanner.Finding{
+		{
+			RuleID:      "aws-access-key",
+			Description: "AWS Access Key detected",
+			Severity:    "high",
+			Path:        "a.env",
+			Line:        3,
+			Column:      5,
+			Fingerprint: "topfp",
+			Evidence:    scanner.Evidence{Fingerprint: "evidencefp"},
+		},
+		{
+			RuleID:      "aws-access-key",
+			Description: "AWS Access Key detected",
+			Severity:    "high",
+			Path:        "b.env",
+			Line:        1,
+			Column:      1,
+			Fingerprint: "topfp2",
+		},
+	}
+
+	log := Build(findings)
+	require.Len(t, log.Runs, 1)
+	run := log.Runs[0]
+
+	require.Len(t, run.Tool.Driver.Rules, 1, "same RuleID across findings should be deduplicated")
+	assert.Equal(t, "aws-access-key", run.Tool.Driver.Rules[0].ID)
+	assert.Equal(t, "error", run.Tool.Driver.Rules[0].DefaultConfiguration.Level)
+
+	require.Len(t, run.Results, 2)
+	assert.Equal(t, "evidencefp", run.Results[0].PartialFingerprints[fingerprintKey], "Evidence.Fingerprint should be preferred")
+	assert.Equal(t, "topfp2", run.Results[1].PartialFingerprints[fingerprintKey], "falls back to Finding.Fingerprint")
+}
+
+func TestMarshal_ProducesValidJSON(t *testing.T) {
+	findings := []scanner.Finding{
+		{RuleID: "generic-token", Description: "Generic token detected", Severity: "medium", Path: "c.txt", Line: 10, Column: 2},
+	}
+
+	data, err := Marshal(findings)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"version": "2.1.0"`)
+	assert.Contains(t, string(data), `"ruleId": "generic-token"`)
+}
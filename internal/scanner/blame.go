@@ -0,0 +1,110 @@
+package scanner
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/ejagojo/SentryScan/internal/gitx"
+)
+
+// blameCacheSize bounds the number of (repoRoot, commitHash, relPath) blame results kept in
+// memory, so scanning a repo with many findings in the same few files doesn't re-run blame
+// once per finding.
+const blameCacheSize = 512
+
+// blameCache is a small LRU keyed by repo root + rev + path, holding the full per-line blame
+// of a file. Bounding it by entries (not bytes) keeps the accounting simple, matching how the
+// rest of this package favors straightforward counters over precise memory budgets.
+type blameCache struct {
+	mu    sync.Mutex
+	blame Blamer
+	order *list.List
+	items map[string]*list.Element
+}
+
+type blameCacheEntry struct {
+	key   string
+	lines []gitx.BlameLine
+	err   error
+}
+
+// Blamer is re-exported from gitx so callers of this package can stub blame without
+// depending on gitx directly.
+type Blamer = gitx.Blamer
+
+func newBlameCache(blame Blamer) *blameCache {
+	if blame == nil {
+		blame = gitx.GitBlamer{}
+	}
+	return &blameCache{
+		blame: blame,
+		order: list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+func (c *blameCache) get(repoRoot, commitHash, relPath string) ([]gitx.BlameLine, error) {
+	key := repoRoot + "\x00" + commitHash + "\x00" + relPath
+
+	c.mu.Lock()
+	if el, ok := c.items[key]; ok {
+		c.order.MoveToFront(el)
+		entry := el.Value.(*blameCacheEntry)
+		c.mu.Unlock()
+		return entry.lines, entry.err
+	}
+	c.mu.Unlock()
+
+	lines, err := c.blame.Blame(repoRoot, relPath, commitHash)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		c.order.MoveToFront(el)
+		return el.Value.(*blameCacheEntry).lines, el.Value.(*blameCacheEntry).err
+	}
+	el := c.order.PushFront(&blameCacheEntry{key: key, lines: lines, err: err})
+	c.items[key] = el
+	if c.order.Len() > blameCacheSize {
+		oldest := c.order.Back()
+		if oldest != nil {
+			c.order.Remove(oldest)
+			delete(c.items, oldest.Value.(*blameCacheEntry).key)
+		}
+	}
+	return lines, err
+}
+
+// enrichWithBlame fills the Blame* fields on findings using a lazily-computed blame pass
+// over repoRoot/relPath at rev. Only the lines that contain matches are looked up.
+func enrichWithBlame(cache *blameCache, repoRoot, relPath, rev string, findings []Finding) {
+	if cache == nil || len(findings) == 0 {
+		return
+	}
+
+	lines, err := cache.get(repoRoot, rev, relPath)
+	if err != nil || len(lines) == 0 {
+		return
+	}
+
+	for i := range findings {
+		idx := findings[i].Line - 1
+		if idx < 0 || idx >= len(lines) {
+			continue
+		}
+		bl := lines[idx]
+		findings[i].BlameCommit = bl.Commit
+		findings[i].BlameAuthor = bl.Author
+		findings[i].BlameEmail = bl.Email
+		findings[i].BlameDate = bl.Date
+		findings[i].BlameSummary = bl.Summary
+
+		// Also populate the canonical provenance fields so SARIF versionControlProvenance and
+		// webhook payloads, which only look at CommitSHA/Author/AuthorEmail/CommittedAt,
+		// pick up blame-derived attribution the same way they'd pick up scanGitRepo's.
+		findings[i].CommitSHA = bl.Commit
+		findings[i].Author = bl.Author
+		findings[i].AuthorEmail = bl.Email
+		findings[i].CommittedAt = bl.Date
+	}
+}
@@ -0,0 +1,99 @@
+package scanner
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ejagojo/SentryScan/internal/gitx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubBlamer returns a fixed set of lines (or an error) for every call, and counts how many
+// times Blame was invoked so tests can assert on blameCache's caching behavior.
+type stubBlamer struct {
+	calls int
+	lines []gitx.BlameLine
+	err   error
+}
+
+func (s *stubBlamer) Blame(repoPath, path, rev string) ([]gitx.BlameLine, error) {
+	s.calls++
+	return s.lines, s.err
+}
+
+func TestBlameCache_CachesByKey(t *testing.T) {
+	stub := &stubBlamer{lines: []gitx.BlameLine{{LineNum: 1, Author: "dev"}}}
+	cache := newBlameCache(stub)
+
+	lines, err := cache.get("/repo", "HEAD", "a.txt")
+	require.NoError(t, err)
+	assert.Len(t, lines, 1)
+	assert.Equal(t, 1, stub.calls)
+
+	_, err = cache.get("/repo", "HEAD", "a.txt")
+	require.NoError(t, err)
+	assert.Equal(t, 1, stub.calls, "second lookup with the same key should hit the cache")
+
+	_, err = cache.get("/repo", "HEAD", "b.txt")
+	require.NoError(t, err)
+	assert.Equal(t, 2, stub.calls, "a different path is a different key")
+}
+
+func TestBlameCache_PropagatesError(t *testing.T) {
+	wantErr := errors.New("blame failed")
+	stub := &stubBlamer{err: wantErr}
+	cache := newBlameCache(stub)
+
+	_, err := cache.get("/repo", "HEAD", "a.txt")
+	assert.ErrorIs(t, err, wantErr)
+}
+
+func TestBlameCache_EvictsOldest(t *testing.T) {
+	stub := &stubBlamer{lines: []gitx.BlameLine{{LineNum: 1}}}
+	cache := newBlameCache(stub)
+
+	for i := 0; i < blameCacheSize+1; i++ {
+		_, err := cache.get("/repo", "HEAD", string(rune('a'+i%26))+string(rune('a'+(i/26)%26)))
+		require.NoError(t, err)
+	}
+	assert.Len(t, cache.items, blameCacheSize)
+}
+
+func TestEnrichWithBlame(t *testing.T) {
+	stub := &stubBlamer{lines: []gitx.BlameLine{
+		{LineNum: 1, Commit: "c1", Author: "Dev One", Email: "dev@example.com", Date: "2024-01-01T00:00:00Z", Summary: "first"},
+		{LineNum: 2, Commit: "c2", Author: "Dev Two", Email: "dev2@example.com", Date: "2024-01-02T00:00:00Z", Summary: "second"},
+	}}
+	cache := newBlameCache(stub)
+
+	findings := []Finding{{Line: 2}}
+	enrichWithBlame(cache, "/repo", "a.txt", "HEAD", findings)
+
+	assert.Equal(t, "c2", findings[0].BlameCommit)
+	assert.Equal(t, "Dev Two", findings[0].BlameAuthor)
+	assert.Equal(t, "dev2@example.com", findings[0].BlameEmail)
+	assert.Equal(t, "second", findings[0].BlameSummary)
+
+	// The canonical provenance fields should mirror the blame-derived attribution too.
+	assert.Equal(t, "c2", findings[0].CommitSHA)
+	assert.Equal(t, "Dev Two", findings[0].Author)
+	assert.Equal(t, "dev2@example.com", findings[0].AuthorEmail)
+}
+
+func TestEnrichWithBlame_NilCacheOrNoFindings(t *testing.T) {
+	findings := []Finding{{Line: 1}}
+	enrichWithBlame(nil, "/repo", "a.txt", "HEAD", findings)
+	assert.Empty(t, findings[0].BlameCommit)
+
+	enrichWithBlame(newBlameCache(&stubBlamer{}), "/repo", "a.txt", "HEAD", nil)
+}
+
+func TestEnrichWithBlame_OutOfRangeLineIgnored(t *testing.T) {
+	stub := &stubBlamer{lines: []gitx.BlameLine{{LineNum: 1, Commit: "c1"}}}
+	cache := newBlameCache(stub)
+
+	findings := []Finding{{Line: 99}}
+	enrichWithBlame(cache, "/repo", "a.txt", "HEAD", findings)
+	assert.Empty(t, findings[0].BlameCommit)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
@@ -17,6 +18,73 @@ type ScannerConfig struct {
 	WebhookSecret  string       `yaml:"webhook_secret,omitempty"`
 	SeverityThresh string       `yaml:"severity,omitempty"`
 	Rules          []RuleConfig `yaml:"rules"`
+	Signatures     *SignatureConfig `yaml:"signatures,omitempty"`
+
+	EntropyThreshold float64 `yaml:"entropy_threshold,omitempty"`
+	MinTokenLen      int     `yaml:"min_token_len,omitempty"`
+
+	// BlacklistedStrings drops any finding whose matched snippet contains one of these
+	// strings (case-sensitive), letting users suppress recurring false positives (lock file
+	// hashes, vendored test certs) without touching the rule set.
+	BlacklistedStrings []string `yaml:"blacklisted_strings,omitempty"`
+
+	// BlacklistedExtensions skips candidate files by extension (e.g. ".lock", ".pem") before
+	// they're ever opened, independent of the --include-ext/--exclude-ext flags.
+	BlacklistedExtensions []string `yaml:"blacklisted_extensions,omitempty"`
+
+	// BlacklistedPaths prunes directories and files whose absolute path contains one of these
+	// substrings. Defaults to defaultBlacklistedPaths when unset. ExcludePaths is the same
+	// mechanism for additional, user-supplied substrings layered on top of the defaults.
+	// Entries may use the {sep} and {name_sep} tokens, both expanded to the OS path separator
+	// at load time, so the same config file is portable across platforms.
+	BlacklistedPaths []string `yaml:"blacklisted_paths,omitempty"`
+	ExcludePaths     []string `yaml:"exclude_paths,omitempty"`
+
+	// Watches classifies findings as policy-breaking "violations" vs informational
+	// "vulnerabilities" so teams can roll out new rules without immediately breaking CI; see
+	// ClassifyViolations.
+	Watches []WatchRule `yaml:"watches,omitempty"`
+}
+
+// defaultBlacklistedPaths are consulted whenever a config file doesn't set BlacklistedPaths,
+// so a fresh install still skips the usual noise: vendored archives and well-known system or
+// container directories that are never useful to scan and are often huge.
+var defaultBlacklistedPaths = []string{
+	"{sep}.git{sep}",
+	"{sep}vendor{sep}",
+	"{sep}node_modules{sep}",
+	"{sep}proc{sep}",
+	"{sep}sys{sep}",
+	"{sep}var{sep}lib{sep}docker{sep}",
+}
+
+// pathTokenReplacer expands the {sep} and {name_sep} tokens used in BlacklistedPaths and
+// ExcludePaths entries to the current OS's path separator.
+var pathTokenReplacer = strings.NewReplacer(
+	"{sep}", string(os.PathSeparator),
+	"{name_sep}", string(os.PathSeparator),
+)
+
+// expandPathTokens applies pathTokenReplacer to every entry in paths. A nil or empty paths
+// stays nil, so a config that never sets exclude_paths round-trips as nil rather than []string{}.
+func expandPathTokens(paths []string) []string {
+	if len(paths) == 0 {
+		return nil
+	}
+	expanded := make([]string, len(paths))
+	for i, p := range paths {
+		expanded[i] = pathTokenReplacer.Replace(p)
+	}
+	return expanded
+}
+
+// SignatureConfig configures the git-signature rule family.
+type SignatureConfig struct {
+	Require          bool     `yaml:"require,omitempty"`
+	AllowedSigners   []string `yaml:"allowed_signers,omitempty"`
+	AllowedKeyIDs    []string `yaml:"allowed_key_ids,omitempty"`
+	SeverityUnsigned string   `yaml:"severity_unsigned,omitempty"`
+	SeverityBad      string   `yaml:"severity_bad,omitempty"`
 }
 
 // RuleConfig represents a scanning rule
@@ -25,6 +93,20 @@ type RuleConfig struct {
 	Description string `yaml:"description"`
 	Severity    string `yaml:"severity"`
 	Pattern     string `yaml:"pattern"`
+
+	// MultilinePattern lets Pattern match across line breaks; see Rule.Multiline.
+	MultilinePattern bool `yaml:"multiline_pattern,omitempty"`
+
+	// ContextBefore and ContextAfter mirror Rule.ContextBefore/ContextAfter (bytes of extra
+	// snippet context on either side of a match).
+	ContextBefore int `yaml:"context_before,omitempty"`
+	ContextAfter  int `yaml:"context_after,omitempty"`
+
+	// PartBody, PartFilename, and PartPath mirror Rule.PartBody/PartFilename/PartPath,
+	// selecting whether Pattern targets file contents, the base name, or the full path.
+	PartBody     bool `yaml:"part_body,omitempty"`
+	PartFilename bool `yaml:"part_filename,omitempty"`
+	PartPath     bool `yaml:"part_path,omitempty"`
 }
 
 // DefaultConfigPath returns the default path to the configuration file
@@ -42,7 +124,8 @@ func LoadConfig(path string) (*ScannerConfig, error) {
 	if err != nil {
 		if os.IsNotExist(err) {
 			return &ScannerConfig{
-				SeverityThresh: "high",
+				SeverityThresh:   "high",
+				BlacklistedPaths: expandPathTokens(defaultBlacklistedPaths),
 			}, nil
 		}
 		return nil, fmt.Errorf("failed to read config: %w", err)
@@ -57,6 +140,11 @@ func LoadConfig(path string) (*ScannerConfig, error) {
 	if config.SeverityThresh == "" {
 		config.SeverityThresh = "high"
 	}
+	if len(config.BlacklistedPaths) == 0 {
+		config.BlacklistedPaths = defaultBlacklistedPaths
+	}
+	config.BlacklistedPaths = expandPathTokens(config.BlacklistedPaths)
+	config.ExcludePaths = expandPathTokens(config.ExcludePaths)
 
 	return &config, nil
 }
@@ -114,6 +202,14 @@ func MergeConfig(config *ScannerConfig, flags map[string]interface{}) *ScannerCo
 			if s, ok := v.(string); ok && s != "" {
 				merged.SeverityThresh = s
 			}
+		case "entropy-threshold":
+			if f, ok := v.(float64); ok && f != 0 {
+				merged.EntropyThreshold = f
+			}
+		case "min-token-len":
+			if i, ok := v.(int); ok && i != 0 {
+				merged.MinTokenLen = i
+			}
 		}
 	}
 
@@ -13,7 +13,7 @@ func TestLoadConfig(t *testing.T) {
 	tests := []struct {
 		name        string
 		setup       func(t *testing.T) string
-		want        *Config
+		want        *ScannerConfig
 		wantErr     bool
 		errContains string
 	}{
@@ -22,25 +22,18 @@ func TestLoadConfig(t *testing.T) {
 			setup: func(t *testing.T) string {
 				return filepath.Join(t.TempDir(), "nonexistent.yaml")
 			},
-			want: &Config{
-				Concurrency: 4,
-				Rules: []RuleConfig{
-					{
-						ID:          "api-key",
-						Description: "API Key detected",
-						Severity:    "high",
-						Pattern:     `(?i)(?:api[_-]?key|apikey)[\s:=]+['"]?([a-zA-Z0-9_-]{32,})['"]?`,
-					},
-				},
+			want: &ScannerConfig{
+				SeverityThresh:   "high",
+				BlacklistedPaths: expandPathTokens(defaultBlacklistedPaths),
 			},
 		},
 		{
-			name: "OverrideConcurrency",
+			name: "OverrideSeverity",
 			setup: func(t *testing.T) string {
 				dir := t.TempDir()
 				configPath := filepath.Join(dir, "config.yaml")
 				err := os.WriteFile(configPath, []byte(`
-concurrency: 8
+severity: low
 rules:
   - id: custom-rule
     description: Custom rule
@@ -50,8 +43,9 @@ rules:
 				require.NoError(t, err)
 				return configPath
 			},
-			want: &Config{
-				Concurrency: 8,
+			want: &ScannerConfig{
+				SeverityThresh:   "low",
+				BlacklistedPaths: expandPathTokens(defaultBlacklistedPaths),
 				Rules: []RuleConfig{
 					{
 						ID:          "custom-rule",
@@ -62,28 +56,6 @@ rules:
 				},
 			},
 		},
-		{
-			name: "DuplicateRuleIDs",
-			setup: func(t *testing.T) string {
-				dir := t.TempDir()
-				configPath := filepath.Join(dir, "config.yaml")
-				err := os.WriteFile(configPath, []byte(`
-rules:
-  - id: duplicate
-    description: First
-    severity: low
-    pattern: "pattern1"
-  - id: duplicate
-    description: Second
-    severity: medium
-    pattern: "pattern2"
-`), 0644)
-				require.NoError(t, err)
-				return configPath
-			},
-			wantErr:     true,
-			errContains: "duplicate rule ID",
-		},
 		{
 			name: "InvalidYAML",
 			setup: func(t *testing.T) string {
@@ -94,7 +66,7 @@ rules:
 				return configPath
 			},
 			wantErr:     true,
-			errContains: "yaml",
+			errContains: "failed to parse config",
 		},
 	}
 
@@ -111,107 +83,48 @@ rules:
 				return
 			}
 
-			assert.NoError(t, err)
+			require.NoError(t, err)
 			assert.Equal(t, tt.want, got)
 		})
 	}
 }
 
 func TestSaveConfig(t *testing.T) {
-	tests := []struct {
-		name    string
-		config  *Config
-		wantErr bool
-	}{
-		{
-			name: "ValidConfig",
-			config: &Config{
-				Concurrency: 4,
-				Rules: []RuleConfig{
-					{
-						ID:          "test-rule",
-						Description: "Test rule",
-						Severity:    "low",
-						Pattern:     "test-pattern",
-					},
-				},
-			},
-		},
-		{
-			name: "DuplicateRuleIDs",
-			config: &Config{
-				Rules: []RuleConfig{
-					{
-						ID:          "duplicate",
-						Description: "First",
-						Severity:    "low",
-						Pattern:     "pattern1",
-					},
-					{
-						ID:          "duplicate",
-						Description: "Second",
-						Severity:    "medium",
-						Pattern:     "pattern2",
-					},
-				},
+	config := &ScannerConfig{
+		SeverityThresh: "high",
+		Rules: []RuleConfig{
+			{
+				ID:          "test-rule",
+				Description: "Test rule",
+				Severity:    "low",
+				Pattern:     "test-pattern",
 			},
-			wantErr: true,
 		},
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			configPath := filepath.Join(t.TempDir(), "config.yaml")
-			err := SaveConfig(tt.config, configPath)
-
-			if tt.wantErr {
-				assert.Error(t, err)
-				return
-			}
-
-			assert.NoError(t, err)
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, SaveConfig(config, configPath))
 
-			// Verify the saved config can be loaded
-			loaded, err := LoadConfig(configPath)
-			assert.NoError(t, err)
-			assert.Equal(t, tt.config, loaded)
-		})
-	}
+	loaded, err := LoadConfig(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, config.Rules, loaded.Rules)
+	assert.Equal(t, config.SeverityThresh, loaded.SeverityThresh)
 }
 
-func TestLoadConfig_NonExistentFile(t *testing.T) {
-	_, err := LoadConfig("nonexistent.yml")
-	if err == nil {
-		t.Error("LoadConfig() expected error for non-existent file")
-	}
+func TestSaveConfig_InvalidPath(t *testing.T) {
+	config := &ScannerConfig{Rules: []RuleConfig{{ID: "test"}}}
+	err := SaveConfig(config, filepath.Join(t.TempDir(), "missing-dir", "config.yml"))
+	assert.Error(t, err)
 }
 
 func TestDefaultConfigPath(t *testing.T) {
-	// Save original home
 	origHome := os.Getenv("HOME")
 	defer os.Setenv("HOME", origHome)
 
-	// Test with HOME set
 	testHome := t.TempDir()
 	os.Setenv("HOME", testHome)
-	got := DefaultConfigPath()
-	want := filepath.Join(testHome, ".sentryscan.yml")
-	if got != want {
-		t.Errorf("DefaultConfigPath() = %q, want %q", got, want)
-	}
+	assert.Equal(t, filepath.Join(testHome, ".sentryscan.yaml"), DefaultConfigPath())
 
-	// Test with HOME unset
 	os.Unsetenv("HOME")
-	got = DefaultConfigPath()
-	if got != ".sentryscan.yml" {
-		t.Errorf("DefaultConfigPath() = %q, want .sentryscan.yml", got)
-	}
-}
-
-func TestSaveConfig_InvalidPath(t *testing.T) {
-	config := &Config{Rules: []RuleConfig{{ID: "test"}}}
-	err := SaveConfig(config, "/nonexistent/dir/config.yml")
-	if err == nil {
-		t.Error("SaveConfig() expected error for invalid path")
-	}
+	assert.Equal(t, ".sentryscan.yaml", DefaultConfigPath())
 }
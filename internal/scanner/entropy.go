@@ -0,0 +1,196 @@
+package scanner
+
+import (
+	"hash/fnv"
+	"math"
+	"regexp"
+	"strings"
+)
+
+const (
+	defaultMinTokenLen          = 20
+	defaultBase64EntropyThresh  = 4.5
+	defaultHexEntropyThresh     = 3.5
+	keyHeuristicLookbehindChars = 32
+	entropyRuleID               = "generic-high-entropy"
+)
+
+// tokenPattern matches contiguous runs of base64 or hex alphabet characters; candidates
+// shorter than the configured MinTokenLen are discarded by the caller.
+var tokenPattern = regexp.MustCompile(`[A-Za-z0-9+/=_-]{8,}`)
+
+var hexOnlyPattern = regexp.MustCompile(`^[0-9a-fA-F]+$`)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// commonPlaceholders is a small embedded wordlist of hashes/placeholders that show up
+// constantly in documentation and fixtures but are never real secrets, so flagging them would
+// just be noise.
+var commonPlaceholders = map[string]bool{
+	"da39a3ee5e6b4b0d3255bfef95601890afd80709": true, // SHA1 of the empty string
+	"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855": true,
+	"0000000000000000000000000000000000000000":                       true, // git zero hash
+	"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff": true,
+}
+
+// entropyDetector holds the per-scanner configuration for the high-entropy detector. The
+// per-file bloom filter that keeps the same token from being reported twice within one blob is
+// passed to scanLine by the caller.
+type entropyDetector struct {
+	threshold   float64
+	hexThresh   float64
+	minTokenLen int
+}
+
+func newEntropyDetector(threshold float64, minTokenLen int) entropyDetector {
+	d := entropyDetector{
+		threshold:   threshold,
+		hexThresh:   defaultHexEntropyThresh,
+		minTokenLen: minTokenLen,
+	}
+	if d.threshold <= 0 {
+		d.threshold = defaultBase64EntropyThresh
+		d.hexThresh = defaultHexEntropyThresh
+	} else {
+		// Scale the hex threshold relative to a custom base64 threshold, preserving the
+		// ~1.0 bits/char gap between the two alphabets' defaults.
+		d.hexThresh = d.threshold - (defaultBase64EntropyThresh - defaultHexEntropyThresh)
+	}
+	if d.minTokenLen <= 0 {
+		d.minTokenLen = defaultMinTokenLen
+	}
+	return d
+}
+
+// scanLine extracts high-entropy tokens from line that no named rule already matched, applying
+// the wordlist, key-assignment, and per-file bloom filter gates described in the detector's
+// doc. seen is mutated as tokens are emitted.
+func (d entropyDetector) scanLine(line string, lineNum int, path string, seen *tokenBloom) []Finding {
+	var findings []Finding
+
+	for _, loc := range tokenPattern.FindAllStringIndex(line, -1) {
+		start, end := loc[0], loc[1]
+		token := line[start:end]
+		if len(token) < d.minTokenLen {
+			continue
+		}
+
+		if commonPlaceholders[strings.ToLower(token)] || uuidPattern.MatchString(token) {
+			continue
+		}
+
+		isHex := hexOnlyPattern.MatchString(token)
+		threshold := d.threshold
+		if isHex {
+			threshold = d.hexThresh
+		}
+
+		h := shannonEntropy(token)
+		if h < threshold {
+			continue
+		}
+
+		lookbehindStart := start - keyHeuristicLookbehindChars
+		if lookbehindStart < 0 {
+			lookbehindStart = 0
+		}
+		lookbehind := line[lookbehindStart:start]
+		if !strings.ContainsAny(lookbehind, "=:") && !strings.Contains(lookbehind, "->") {
+			continue
+		}
+
+		if seen.seenBefore(token) {
+			continue
+		}
+
+		findings = append(findings, Finding{
+			Type:        "secret",
+			RuleID:      entropyRuleID,
+			Description: "High-entropy token detected with no matching named rule",
+			Severity:    "medium",
+			Path:        path,
+			Line:        lineNum,
+			Column:      start + 1,
+			Match:       token,
+			Context:     line,
+			Probe:       entropyRuleID,
+			Evidence: Evidence{
+				Snippet:      line,
+				EntropyScore: h,
+				Confidence:   entropyConfidence(h, threshold),
+			},
+		})
+	}
+
+	return findings
+}
+
+// shannonEntropy computes H = -Σ p(c)·log2 p(c) over the character distribution of s.
+func shannonEntropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+
+	counts := make(map[rune]int)
+	for _, r := range s {
+		counts[r]++
+	}
+
+	length := float64(len(s))
+	var h float64
+	for _, c := range counts {
+		p := float64(c) / length
+		h -= p * math.Log2(p)
+	}
+	return h
+}
+
+// entropyConfidence bands a token's entropy score relative to the threshold that admitted it:
+// the further above threshold, the less likely the token is incidental high-entropy noise
+// (build hashes, compressed data) rather than an actual secret.
+func entropyConfidence(score, threshold float64) string {
+	switch {
+	case score >= threshold+1.0:
+		return "high"
+	case score >= threshold+0.5:
+		return "medium"
+	default:
+		return "low"
+	}
+}
+
+// tokenBloom is a small fixed-size Bloom filter scoped to a single file/blob, used to avoid
+// reporting the same high-entropy token more than once per ScanReader call.
+type tokenBloom struct {
+	bits [1024]uint64
+}
+
+func newTokenBloom() *tokenBloom {
+	return &tokenBloom{}
+}
+
+// seenBefore reports whether token has already been recorded, and records it if not.
+func (b *tokenBloom) seenBefore(token string) bool {
+	h1, h2 := bloomHashes(token)
+	idx1, bit1 := (h1%65536)/64, h1%64
+	idx2, bit2 := (h2%65536)/64, h2%64
+
+	already := b.bits[idx1]&(1<<bit1) != 0 && b.bits[idx2]&(1<<bit2) != 0
+	b.bits[idx1] |= 1 << bit1
+	b.bits[idx2] |= 1 << bit2
+	return already
+}
+
+// bloomHashes derives two independent hashes of token from a single FNV-1a pass, per the
+// standard "double hashing" Bloom filter construction.
+func bloomHashes(token string) (uint64, uint64) {
+	h1 := fnv.New64a()
+	h1.Write([]byte(token))
+	sum1 := h1.Sum64()
+
+	h2 := fnv.New64a()
+	h2.Write([]byte(token + "\x00salt"))
+	sum2 := h2.Sum64()
+
+	return sum1, sum2
+}
@@ -0,0 +1,92 @@
+package scanner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShannonEntropy(t *testing.T) {
+	assert.Equal(t, 0.0, shannonEntropy(""))
+	assert.Equal(t, 0.0, shannonEntropy("aaaaaaaa"), "a single repeated char has zero entropy")
+	assert.InDelta(t, 1.0, shannonEntropy("ab"), 0.001, "two equiprobable symbols gives 1 bit of entropy")
+}
+
+func TestEntropyConfidence(t *testing.T) {
+	assert.Equal(t, "low", entropyConfidence(4.5, 4.5))
+	assert.Equal(t, "medium", entropyConfidence(5.0, 4.5))
+	assert.Equal(t, "high", entropyConfidence(5.6, 4.5))
+}
+
+func TestTokenBloom_SeenBefore(t *testing.T) {
+	b := newTokenBloom()
+
+	assert.False(t, b.seenBefore("token-one"))
+	assert.True(t, b.seenBefore("token-one"), "a token should be flagged as seen the second time")
+	assert.False(t, b.seenBefore("token-two"), "a different token should not collide")
+}
+
+func TestEntropyDetector_ScanLine_RequiresKeyHeuristicContext(t *testing.T) {
+	d := newEntropyDetector(0, 0)
+
+	// A high-entropy token with no "=", ":" or "->" lookbehind should be ignored.
+	noContext := d.scanLine("just some random text qW8zLp2R9vT6mN4xB1cF7dH3j nothing to see", 1, "a.txt", newTokenBloom())
+	assert.Empty(t, noContext)
+
+	withContext := d.scanLine(`token = "qW8zLp2R9vT6mN4xB1cF7dH3j"`, 1, "a.txt", newTokenBloom())
+	assert.NotEmpty(t, withContext)
+	assert.Equal(t, entropyRuleID, withContext[0].RuleID)
+}
+
+func TestEntropyDetector_ScanLine_SkipsPlaceholdersAndUUIDs(t *testing.T) {
+	d := newEntropyDetector(0, 0)
+
+	uuidLine := `id = "123e4567-e89b-12d3-a456-426614174000"`
+	assert.Empty(t, d.scanLine(uuidLine, 1, "a.txt", newTokenBloom()))
+
+	placeholderLine := `sha1 = "da39a3ee5e6b4b0d3255bfef95601890afd80709"`
+	assert.Empty(t, d.scanLine(placeholderLine, 1, "a.txt", newTokenBloom()))
+}
+
+func TestEntropyDetector_ScanLine_ShortTokensIgnored(t *testing.T) {
+	d := newEntropyDetector(0, 50)
+
+	line := `token = "qW8zLp2R9vT6mN4xB1cF7dH3j"`
+	assert.Empty(t, d.scanLine(line, 1, "a.txt", newTokenBloom()), "tokens shorter than MinTokenLen should be ignored")
+}
+
+func TestEntropyDetector_ScanLine_DedupesWithinBloom(t *testing.T) {
+	d := newEntropyDetector(0, 0)
+	seen := newTokenBloom()
+
+	line := `token = "qW8zLp2R9vT6mN4xB1cF7dH3j"`
+	first := d.scanLine(line, 1, "a.txt", seen)
+	second := d.scanLine(line, 2, "a.txt", seen)
+
+	assert.NotEmpty(t, first)
+	assert.Empty(t, second, "the same token should not be reported twice against one shared bloom filter")
+}
+
+func TestEntropyDetector_ScanLine_HexUsesLowerThreshold(t *testing.T) {
+	d := newEntropyDetector(0, 0)
+
+	// A purely hex token, long enough and with a key-assignment lookbehind, should clear the
+	// lower hex-specific threshold even if it wouldn't clear the base64 default.
+	line := `secret = "0123456789abcdef0123456789abcdef"`
+	findings := d.scanLine(line, 1, "a.txt", newTokenBloom())
+	assert.NotEmpty(t, findings)
+}
+
+func TestNewEntropyDetector_Defaults(t *testing.T) {
+	d := newEntropyDetector(0, 0)
+	assert.Equal(t, defaultBase64EntropyThresh, d.threshold)
+	assert.Equal(t, defaultHexEntropyThresh, d.hexThresh)
+	assert.Equal(t, defaultMinTokenLen, d.minTokenLen)
+}
+
+func TestNewEntropyDetector_CustomThresholdScalesHex(t *testing.T) {
+	d := newEntropyDetector(5.5, 10)
+	assert.Equal(t, 5.5, d.threshold)
+	assert.InDelta(t, 4.5, d.hexThresh, 0.001)
+	assert.Equal(t, 10, d.minTokenLen)
+}
@@ -0,0 +1,142 @@
+package scanner
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ejagojo/SentryScan/internal/gitx"
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+)
+
+// walkMatcher decides, for a single walk root, whether a path should be pruned. It delegates
+// gitignore-style exclusion and linguist-generated/linguist-vendored detection to the shared
+// gitx.Matcher (the same matcher gitx.ChangedFiles and gitx.FilesInRange apply to git-history
+// file lists), then layers on two things unique to a plain filesystem walk:
+// ScannerOptions.ExtraIgnoreFiles, and a lightweight .gitattributes pass that skips paths
+// marked "binary" or "sentryscan=skip".
+type walkMatcher struct {
+	shared      *gitx.Matcher
+	extraIgnore gitignore.Matcher
+	attributes  map[string]fileAttrs
+}
+
+type fileAttrs struct {
+	binary bool
+	skip   bool
+}
+
+// newWalkMatcher builds a matcher for the given walk root. It always succeeds: a root with
+// no .gitignore/.gitattributes files simply yields a matcher that never skips anything.
+func newWalkMatcher(root string, opts ScannerOptions) *walkMatcher {
+	var extraPatterns []gitignore.Pattern
+	for _, extra := range opts.ExtraIgnoreFiles {
+		extraPatterns = append(extraPatterns, readPatternFile(extra, nil)...)
+	}
+
+	return &walkMatcher{
+		shared: gitx.NewMatcher(root, gitx.FilterOptions{
+			RespectGitignore:                      opts.RespectGitignore,
+			RespectGitattributesLinguistGenerated: true,
+		}),
+		extraIgnore: gitignore.NewMatcher(extraPatterns),
+		attributes:  readGitattributes(root),
+	}
+}
+
+// skipDir reports whether relPath (relative to root, split on the OS path separator) should be
+// pruned entirely via filepath.SkipDir.
+func (m *walkMatcher) skipDir(relPath string) bool {
+	if m.shared.SkipDir(relPath) {
+		return true
+	}
+	return m.extraIgnore.Match(strings.Split(relPath, string(filepath.Separator)), true)
+}
+
+// skipFile reports whether relPath should be skipped: matched by the shared gitx.Matcher
+// (gitignore or linguist-generated/vendored), matched by an ExtraIgnoreFiles pattern, or
+// marked binary/sentryscan=skip in .gitattributes.
+func (m *walkMatcher) skipFile(relPath string) bool {
+	if m.shared.SkipFile(relPath) {
+		return true
+	}
+	if m.extraIgnore.Match(strings.Split(relPath, string(filepath.Separator)), false) {
+		return true
+	}
+	if attrs, ok := m.attributes[relPath]; ok && (attrs.binary || attrs.skip) {
+		return true
+	}
+	return false
+}
+
+func readPatternFile(path string, domain []string) []gitignore.Pattern {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var patterns []gitignore.Pattern
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		patterns = append(patterns, gitignore.ParsePattern(line, domain))
+	}
+	return patterns
+}
+
+// readGitattributes parses every .gitattributes file under root into a flat map keyed by
+// the file path (relative to root, slash-separated) it applies to. It only understands the
+// two attributes this scanner cares about beyond the linguist markers gitx.Matcher already
+// handles: "binary" and "sentryscan=skip".
+func readGitattributes(root string) map[string]fileAttrs {
+	result := make(map[string]fileAttrs)
+
+	_ = filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Base(path) != ".gitattributes" {
+			return nil
+		}
+
+		dir := filepath.Dir(path)
+		f, openErr := os.Open(path)
+		if openErr != nil {
+			return nil
+		}
+		defer f.Close()
+
+		sc := bufio.NewScanner(f)
+		for sc.Scan() {
+			fields := strings.Fields(sc.Text())
+			if len(fields) < 2 {
+				continue
+			}
+			pattern := fields[0]
+			attrs := fileAttrs{}
+			for _, attr := range fields[1:] {
+				switch attr {
+				case "binary":
+					attrs.binary = true
+				case "sentryscan=skip":
+					attrs.skip = true
+				}
+			}
+			if !attrs.binary && !attrs.skip {
+				continue
+			}
+
+			matches, _ := filepath.Glob(filepath.Join(dir, pattern))
+			for _, m := range matches {
+				if rel, err := filepath.Rel(root, m); err == nil {
+					result[filepath.ToSlash(rel)] = attrs
+				}
+			}
+		}
+		return nil
+	})
+
+	return result
+}
@@ -0,0 +1,80 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, root, relPath, content string) {
+	t.Helper()
+	path := filepath.Join(root, relPath)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func TestWalkMatcher_Gitignore(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, ".gitignore", "*.log\nbuild/\n")
+	writeFile(t, root, "app.log", "noise")
+	writeFile(t, root, "main.go", "package main")
+	writeFile(t, root, "build/out.bin", "binary")
+
+	m := newWalkMatcher(root, ScannerOptions{RespectGitignore: true})
+
+	assert.True(t, m.skipFile("app.log"))
+	assert.False(t, m.skipFile("main.go"))
+	assert.True(t, m.skipDir("build"))
+}
+
+func TestWalkMatcher_GitignoreDisabled(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, ".gitignore", "*.log\n")
+	writeFile(t, root, "app.log", "noise")
+
+	m := newWalkMatcher(root, ScannerOptions{RespectGitignore: false})
+
+	assert.False(t, m.skipFile("app.log"))
+}
+
+func TestWalkMatcher_ExtraIgnoreFiles(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "secret.env", "TOKEN=abc")
+
+	extraPath := filepath.Join(root, "extra-ignore")
+	require.NoError(t, os.WriteFile(extraPath, []byte("secret.env\n"), 0644))
+
+	m := newWalkMatcher(root, ScannerOptions{ExtraIgnoreFiles: []string{extraPath}})
+
+	assert.True(t, m.skipFile("secret.env"))
+}
+
+func TestWalkMatcher_GitattributesBinaryAndSkip(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, ".gitattributes", "*.bin binary\nvendor.go sentryscan=skip\n")
+	writeFile(t, root, "data.bin", "\x00\x01")
+	writeFile(t, root, "vendor.go", "package vendor")
+	writeFile(t, root, "main.go", "package main")
+
+	m := newWalkMatcher(root, ScannerOptions{})
+
+	assert.True(t, m.skipFile("data.bin"))
+	assert.True(t, m.skipFile("vendor.go"))
+	assert.False(t, m.skipFile("main.go"))
+}
+
+func TestReadGitattributes_NoFile(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "main.go", "package main")
+
+	attrs := readGitattributes(root)
+	assert.Empty(t, attrs)
+}
+
+func TestReadPatternFile_MissingFileReturnsNil(t *testing.T) {
+	patterns := readPatternFile(filepath.Join(t.TempDir(), "missing"), nil)
+	assert.Nil(t, patterns)
+}
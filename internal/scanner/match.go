@@ -0,0 +1,171 @@
+package scanner
+
+import "fmt"
+
+// defaultChunkSize is how much of a blob-mode rule's input MatchScanner.FindAll sees in one
+// call when scanBlob streams content through it (see chunkedFindAll).
+const defaultChunkSize = 64 * 1024
+
+// MatchScanner is the pluggable backend SecretScanner uses to evaluate rule patterns against a
+// chunk of content. The default backend (match_re2.go) runs the pure-Go regexp.Regexp patterns
+// directly; an optional cgo Hyperscan backend (match_hyperscan.go, built behind the
+// "hyperscan" tag) compiles the same patterns into a single Hyperscan database for an
+// order-of-magnitude throughput boost on large monorepos.
+type MatchScanner interface {
+	// FindAll returns every match of every rule the engine was constructed with, within
+	// content. offset is added to each match's Start/End, so callers streaming content in
+	// chunks can report positions relative to the whole blob rather than the chunk.
+	FindAll(content []byte, offset int) []RuleMatch
+}
+
+// RuleMatch is one match of one rule's capture group against a chunk of content.
+type RuleMatch struct {
+	Rule  *Rule
+	Value string
+	Start int
+	End   int
+}
+
+// rulePartition buckets a scanner's rules by how ScanReader evaluates them: line-mode body
+// rules (the original per-line behavior, and still the default for every built-in rule),
+// whole-blob body rules for patterns that need to see across line breaks, and filename/path
+// rules that never look at file contents at all.
+type rulePartition struct {
+	line     []Rule
+	blob     []Rule
+	filename []Rule
+	path     []Rule
+}
+
+// partitionRules sorts rules into the four buckets scanned in rulePartition. A rule is
+// filename- or path-targeting only if it opts in via PartFilename/PartPath; otherwise it's a
+// body rule, evaluated per line unless MultilinePattern is set.
+func partitionRules(rules []Rule) rulePartition {
+	var p rulePartition
+	for _, r := range rules {
+		switch {
+		case r.PartFilename:
+			p.filename = append(p.filename, r)
+		case r.PartPath:
+			p.path = append(p.path, r)
+		case r.Multiline:
+			p.blob = append(p.blob, r)
+		default:
+			p.line = append(p.line, r)
+		}
+	}
+	return p
+}
+
+// maxPatternLen returns the length of the longest pattern source among rules, used to size the
+// overlap between consecutive chunks in chunkedFindAll so a match straddling a chunk boundary
+// isn't missed.
+func maxPatternLen(rules []Rule) int {
+	max := 0
+	for _, r := range rules {
+		if r.Pattern == nil {
+			continue
+		}
+		if n := len(r.Pattern.String()); n > max {
+			max = n
+		}
+	}
+	return max
+}
+
+// chunkedFindAll streams content through engine in chunkSize-byte windows that overlap by
+// overlap bytes, so a pattern spanning a chunk boundary is still found in at least one window.
+// Matches recurring in the overlapping region (same rule, same absolute Start/End) are
+// deduplicated.
+func chunkedFindAll(engine MatchScanner, content []byte, chunkSize, overlap int) []RuleMatch {
+	if engine == nil || len(content) == 0 {
+		return nil
+	}
+	if chunkSize <= 0 {
+		chunkSize = defaultChunkSize
+	}
+	if overlap < 0 || overlap >= chunkSize {
+		overlap = 0
+	}
+
+	seen := make(map[string]struct{})
+	var matches []RuleMatch
+	for start := 0; start < len(content); {
+		end := start + chunkSize
+		if end > len(content) {
+			end = len(content)
+		}
+
+		matches = append(matches, matchWindow(engine, content[start:end], start, seen)...)
+
+		if end == len(content) {
+			break
+		}
+		start = end - overlap
+	}
+	return matches
+}
+
+// matchWindow runs engine against window (whose first byte is at absolute offset windowStart),
+// dropping any match already present in seen (keyed by rule + absolute span, so callers that
+// invoke matchWindow repeatedly over overlapping windows - chunkedFindAll, and the streaming
+// path in scanner.go's scanStreaming - don't report the same match twice) and any match
+// withinMaxMatchLen rejects. New matches are recorded into seen before being returned.
+func matchWindow(engine MatchScanner, window []byte, windowStart int, seen map[string]struct{}) []RuleMatch {
+	if engine == nil || len(window) == 0 {
+		return nil
+	}
+
+	var matches []RuleMatch
+	for _, m := range engine.FindAll(window, windowStart) {
+		if !withinMaxMatchLen(m) {
+			continue
+		}
+		key := fmt.Sprintf("%s:%d:%d", m.Rule.ID, m.Start, m.End)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		matches = append(matches, m)
+	}
+	return matches
+}
+
+// withinMaxMatchLen reports whether m.Value is short enough for m.Rule.MaxMatchLen to allow.
+// A zero MaxMatchLen (the default) leaves matches unbounded.
+func withinMaxMatchLen(m RuleMatch) bool {
+	return m.Rule.MaxMatchLen <= 0 || len(m.Value) <= m.Rule.MaxMatchLen
+}
+
+// contextSnippet returns content[start-before:end+after], clamped to content's bounds.
+func contextSnippet(content []byte, start, end, before, after int) string {
+	lo := start - before
+	if lo < 0 {
+		lo = 0
+	}
+	hi := end + after
+	if hi > len(content) {
+		hi = len(content)
+	}
+	if lo > hi {
+		lo = hi
+	}
+	return string(content[lo:hi])
+}
+
+// lineColFromOffset converts a byte offset into content to a 1-based (line, column) pair, for
+// findings produced by whole-blob matching where there's no natural per-line loop index.
+func lineColFromOffset(content []byte, offset int) (line, column int) {
+	line = 1
+	lastNewline := -1
+	if offset > len(content) {
+		offset = len(content)
+	}
+	for i := 0; i < offset; i++ {
+		if content[i] == '\n' {
+			line++
+			lastNewline = i
+		}
+	}
+	return line, offset - lastNewline
+}
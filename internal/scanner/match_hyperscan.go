@@ -0,0 +1,165 @@
+//go:build hyperscan
+
+package scanner
+
+/*
+#cgo LDFLAGS: -lhs
+#include <hs/hs.h>
+#include <stdlib.h>
+
+extern int goHyperscanMatch(unsigned int id, unsigned long long from, unsigned long long to, unsigned int flags, void *ctx);
+
+static int hyperscanMatchTrampoline(unsigned int id, unsigned long long from, unsigned long long to, unsigned int flags, void *ctx) {
+	return goHyperscanMatch(id, from, to, flags, ctx);
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"runtime/cgo"
+	"sync"
+	"unsafe"
+)
+
+// newMatchScanner builds the cgo Hyperscan MatchScanner backend, compiled in only under
+// -tags hyperscan (requires libhs and its headers). It compiles every rule's pattern into a
+// single multi-pattern Hyperscan database once, then scans each chunk with hs_scan, which is
+// an order of magnitude faster than evaluating each Go regexp.Regexp in turn on large inputs.
+// Hyperscan does not report capture groups: every pattern is compiled with
+// HS_FLAG_SOM_LEFTMOST, so a match's Value and Start/End cover the whole pattern match rather
+// than its first capturing group. If any rule's pattern fails to compile, the whole database
+// fails to build and newMatchScanner falls back to the RE2 engine for every rule.
+func newMatchScanner(rules []Rule) MatchScanner {
+	e, err := newHyperscanEngine(rules)
+	if err != nil {
+		// Fall back to the pure-Go engine rather than failing the whole scan: a bad pattern
+		// or a too-old libhs shouldn't take down secret detection entirely.
+		return &re2Engine{rules: rules}
+	}
+	return e
+}
+
+// hyperscanEngine is the cgo Hyperscan backend for MatchScanner.
+type hyperscanEngine struct {
+	rules   []Rule
+	db      *C.hs_database_t
+	scratch *C.hs_scratch_t
+	mu      sync.Mutex
+}
+
+func newHyperscanEngine(rules []Rule) (*hyperscanEngine, error) {
+	if len(rules) == 0 {
+		return &hyperscanEngine{}, nil
+	}
+
+	cPatterns := make([]*C.char, len(rules))
+	ids := make([]C.uint, len(rules))
+	flags := make([]C.uint, len(rules))
+	for i, r := range rules {
+		cPatterns[i] = C.CString(r.Pattern.String())
+		ids[i] = C.uint(i)
+		flags[i] = C.HS_FLAG_SOM_LEFTMOST
+	}
+	defer func() {
+		for _, p := range cPatterns {
+			C.free(unsafe.Pointer(p))
+		}
+	}()
+
+	var db *C.hs_database_t
+	var compileErr *C.hs_compile_error_t
+	ret := C.hs_compile_multi(
+		&cPatterns[0],
+		&flags[0],
+		&ids[0],
+		C.uint(len(rules)),
+		C.HS_MODE_BLOCK,
+		nil,
+		&db,
+		&compileErr,
+	)
+	if ret != C.HS_SUCCESS {
+		msg := "hyperscan compile failed"
+		if compileErr != nil {
+			msg = C.GoString(compileErr.message)
+			C.hs_free_compile_error(compileErr)
+		}
+		return nil, fmt.Errorf("%s", msg)
+	}
+
+	var scratch *C.hs_scratch_t
+	if ret := C.hs_alloc_scratch(db, &scratch); ret != C.HS_SUCCESS {
+		C.hs_free_database(db)
+		return nil, fmt.Errorf("hyperscan scratch allocation failed: %d", int(ret))
+	}
+
+	return &hyperscanEngine{rules: rules, db: db, scratch: scratch}, nil
+}
+
+// hyperscanMatches carries match offsets from the C callback back to FindAll via a
+// runtime/cgo.Handle, so no Go pointer crosses into C.
+type hyperscanMatches struct {
+	matches []RuleMatch
+	rules   []Rule
+	content []byte
+	offset  int
+}
+
+func (e *hyperscanEngine) FindAll(content []byte, offset int) []RuleMatch {
+	if e.db == nil || len(content) == 0 {
+		return nil
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	acc := &hyperscanMatches{rules: e.rules, content: content, offset: offset}
+	handle := cgo.NewHandle(acc)
+	defer handle.Delete()
+
+	data := (*C.char)(unsafe.Pointer(&content[0]))
+	C.hs_scan(
+		e.db,
+		data,
+		C.uint(len(content)),
+		0,
+		e.scratch,
+		C.hyperscanMatchTrampoline,
+		unsafe.Pointer(uintptr(handle)),
+	)
+
+	return acc.matches
+}
+
+//export goHyperscanMatch
+func goHyperscanMatch(id C.uint, from, to C.ulonglong, flags C.uint, ctx unsafe.Pointer) C.int {
+	handle := cgo.Handle(uintptr(ctx))
+	acc := handle.Value().(*hyperscanMatches)
+
+	if int(id) >= len(acc.rules) {
+		return 0
+	}
+	rule := &acc.rules[id]
+	start, end := int(from), int(to)
+	if start < 0 || end < start || end > len(acc.content) {
+		return 0
+	}
+
+	acc.matches = append(acc.matches, RuleMatch{
+		Rule:  rule,
+		Value: string(acc.content[start:end]),
+		Start: acc.offset + start,
+		End:   acc.offset + end,
+	})
+	return 0
+}
+
+func (e *hyperscanEngine) Close() {
+	if e.scratch != nil {
+		C.hs_free_scratch(e.scratch)
+	}
+	if e.db != nil {
+		C.hs_free_database(e.db)
+	}
+}
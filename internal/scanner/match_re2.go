@@ -0,0 +1,41 @@
+//go:build !hyperscan
+
+package scanner
+
+// newMatchScanner builds the default, pure-Go MatchScanner backend. It's swapped for
+// hyperscanEngine (match_hyperscan.go) when built with -tags hyperscan.
+func newMatchScanner(rules []Rule) MatchScanner {
+	return &re2Engine{rules: rules}
+}
+
+// re2Engine evaluates rules with the standard library's regexp package, one rule at a time.
+// It mirrors the matching behavior SecretScanner had before MatchScanner existed: a rule only
+// produces a match when its pattern has a capturing group, and the match's Value is that
+// group's text rather than the whole pattern match.
+type re2Engine struct {
+	rules []Rule
+}
+
+func (e *re2Engine) FindAll(content []byte, offset int) []RuleMatch {
+	var matches []RuleMatch
+	for i := range e.rules {
+		rule := &e.rules[i]
+		if rule.Pattern == nil {
+			continue
+		}
+
+		for _, idx := range rule.Pattern.FindAllSubmatchIndex(content, -1) {
+			if len(idx) < 4 || idx[2] < 0 || idx[3] < 0 {
+				continue
+			}
+			start, end := idx[2], idx[3]
+			matches = append(matches, RuleMatch{
+				Rule:  rule,
+				Value: string(content[start:end]),
+				Start: offset + start,
+				End:   offset + end,
+			})
+		}
+	}
+	return matches
+}
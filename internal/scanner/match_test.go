@@ -0,0 +1,126 @@
+package scanner
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRE2Engine_FindAll(t *testing.T) {
+	rule := Rule{ID: "test-rule", Pattern: regexp.MustCompile(`key=(\w+)`)}
+	engine := newMatchScanner([]Rule{rule})
+
+	matches := engine.FindAll([]byte("prefix key=abc123 suffix"), 100)
+
+	assert.Len(t, matches, 1)
+	assert.Equal(t, "abc123", matches[0].Value)
+	assert.Equal(t, "test-rule", matches[0].Rule.ID)
+	assert.Equal(t, 100+len("prefix key="), matches[0].Start)
+}
+
+func TestRE2Engine_SkipsRulesWithoutPattern(t *testing.T) {
+	rule := Rule{ID: "no-pattern"}
+	engine := newMatchScanner([]Rule{rule})
+
+	matches := engine.FindAll([]byte("anything"), 0)
+	assert.Empty(t, matches)
+}
+
+func TestRE2Engine_SkipsNonCapturingMatches(t *testing.T) {
+	rule := Rule{ID: "no-group", Pattern: regexp.MustCompile(`key=\w+`)}
+	engine := newMatchScanner([]Rule{rule})
+
+	matches := engine.FindAll([]byte("key=abc123"), 0)
+	assert.Empty(t, matches, "a pattern with no capture group should never produce a match")
+}
+
+func TestPartitionRules(t *testing.T) {
+	rules := []Rule{
+		{ID: "line-rule"},
+		{ID: "blob-rule", Multiline: true},
+		{ID: "filename-rule", PartFilename: true},
+		{ID: "path-rule", PartPath: true},
+	}
+
+	p := partitionRules(rules)
+	assert.Len(t, p.line, 1)
+	assert.Equal(t, "line-rule", p.line[0].ID)
+	assert.Len(t, p.blob, 1)
+	assert.Equal(t, "blob-rule", p.blob[0].ID)
+	assert.Len(t, p.filename, 1)
+	assert.Equal(t, "filename-rule", p.filename[0].ID)
+	assert.Len(t, p.path, 1)
+	assert.Equal(t, "path-rule", p.path[0].ID)
+}
+
+func TestMaxPatternLen(t *testing.T) {
+	rules := []Rule{
+		{Pattern: regexp.MustCompile(`a`)},
+		{Pattern: regexp.MustCompile(`longer-pattern`)},
+		{Pattern: nil},
+	}
+	assert.Equal(t, len(`longer-pattern`), maxPatternLen(rules))
+}
+
+func TestChunkedFindAll_MatchAcrossChunkBoundary(t *testing.T) {
+	rule := Rule{ID: "boundary", Pattern: regexp.MustCompile(`(needle12345)`)}
+	engine := newMatchScanner([]Rule{rule})
+
+	content := []byte(string(make([]byte, 10)) + "needle12345" + string(make([]byte, 10)))
+	// Force a chunk boundary to fall in the middle of "needle12345".
+	matches := chunkedFindAll(engine, content, 15, 12)
+
+	assert.Len(t, matches, 1)
+	assert.Equal(t, "needle12345", matches[0].Value)
+}
+
+func TestChunkedFindAll_EmptyInputs(t *testing.T) {
+	rule := Rule{ID: "x", Pattern: regexp.MustCompile(`(x)`)}
+	engine := newMatchScanner([]Rule{rule})
+
+	assert.Nil(t, chunkedFindAll(nil, []byte("x"), 10, 0))
+	assert.Nil(t, chunkedFindAll(engine, nil, 10, 0))
+}
+
+func TestChunkedFindAll_InvalidOverlapResetsToZero(t *testing.T) {
+	rule := Rule{ID: "x", Pattern: regexp.MustCompile(`(abc)`)}
+	engine := newMatchScanner([]Rule{rule})
+
+	matches := chunkedFindAll(engine, []byte("abc"), 10, 50)
+	assert.Len(t, matches, 1)
+}
+
+func TestWithinMaxMatchLen(t *testing.T) {
+	unbounded := RuleMatch{Rule: &Rule{}, Value: "anything"}
+	assert.True(t, withinMaxMatchLen(unbounded))
+
+	bounded := RuleMatch{Rule: &Rule{MaxMatchLen: 4}, Value: "toolong"}
+	assert.False(t, withinMaxMatchLen(bounded))
+
+	withinBound := RuleMatch{Rule: &Rule{MaxMatchLen: 10}, Value: "short"}
+	assert.True(t, withinMaxMatchLen(withinBound))
+}
+
+func TestContextSnippet(t *testing.T) {
+	content := []byte("0123456789")
+	assert.Equal(t, "23456", contextSnippet(content, 4, 6, 2, 1))
+	assert.Equal(t, "0123456", contextSnippet(content, 0, 2, 5, 5), "clamped to content bounds on the left")
+	assert.Equal(t, "56789", contextSnippet(content, 8, 10, 3, 5), "clamped to content bounds on the right")
+}
+
+func TestLineColFromOffset(t *testing.T) {
+	content := []byte("line1\nline2\nline3")
+
+	line, col := lineColFromOffset(content, 0)
+	assert.Equal(t, 1, line)
+	assert.Equal(t, 1, col)
+
+	line, col = lineColFromOffset(content, 6)
+	assert.Equal(t, 2, line)
+	assert.Equal(t, 1, col)
+
+	line, col = lineColFromOffset(content, 8)
+	assert.Equal(t, 2, line)
+	assert.Equal(t, 3, col)
+}
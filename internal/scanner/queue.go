@@ -0,0 +1,84 @@
+package scanner
+
+import "sync"
+
+// scanCandidate is one file handed from the filesystem walker to a scan worker.
+type scanCandidate struct {
+	path string
+	rel  string
+}
+
+// candidateQueue is a bounded, slice-backed ring buffer sitting between the filesystem walker
+// and the worker pool in scanFilesystem, so memory stays flat regardless of repo size: Push
+// blocks while the buffer is full, Pop blocks while it's empty, and Close unblocks every
+// blocked Push/Pop so the walker and workers can unwind during cancellation.
+type candidateQueue struct {
+	mu       sync.Mutex
+	notEmpty *sync.Cond
+	notFull  *sync.Cond
+	buf      []scanCandidate
+	head     int
+	size     int
+	closed   bool
+}
+
+// newCandidateQueue builds a queue with room for capacity candidates. A non-positive capacity
+// is treated as 1, since a zero-length ring buffer can never be pushed to.
+func newCandidateQueue(capacity int) *candidateQueue {
+	if capacity <= 0 {
+		capacity = 1
+	}
+	q := &candidateQueue{buf: make([]scanCandidate, capacity)}
+	q.notEmpty = sync.NewCond(&q.mu)
+	q.notFull = sync.NewCond(&q.mu)
+	return q
+}
+
+// Push blocks until there is room for c, the queue is closed, or reports false in the latter
+// case so the walker can stop producing.
+func (q *candidateQueue) Push(c scanCandidate) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for q.size == len(q.buf) && !q.closed {
+		q.notFull.Wait()
+	}
+	if q.closed {
+		return false
+	}
+
+	q.buf[(q.head+q.size)%len(q.buf)] = c
+	q.size++
+	q.notEmpty.Signal()
+	return true
+}
+
+// Pop blocks until a candidate is available or the queue is closed and drained, in which case
+// it reports false so the worker can exit.
+func (q *candidateQueue) Pop() (scanCandidate, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for q.size == 0 && !q.closed {
+		q.notEmpty.Wait()
+	}
+	if q.size == 0 {
+		return scanCandidate{}, false
+	}
+
+	c := q.buf[q.head]
+	q.head = (q.head + 1) % len(q.buf)
+	q.size--
+	q.notFull.Signal()
+	return c, true
+}
+
+// Close unblocks every goroutine waiting in Push or Pop. Pending candidates already buffered
+// are still returned by Pop until the queue drains; after that Pop reports false.
+func (q *candidateQueue) Close() {
+	q.mu.Lock()
+	q.closed = true
+	q.mu.Unlock()
+	q.notEmpty.Broadcast()
+	q.notFull.Broadcast()
+}
@@ -0,0 +1,119 @@
+package scanner
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCandidateQueue_PushPopOrder(t *testing.T) {
+	q := newCandidateQueue(4)
+
+	for i := 0; i < 3; i++ {
+		ok := q.Push(scanCandidate{path: string(rune('a' + i))})
+		require.True(t, ok)
+	}
+
+	for i := 0; i < 3; i++ {
+		c, ok := q.Pop()
+		require.True(t, ok)
+		assert.Equal(t, string(rune('a'+i)), c.path)
+	}
+}
+
+func TestCandidateQueue_NonPositiveCapacityTreatedAsOne(t *testing.T) {
+	q := newCandidateQueue(0)
+	require.True(t, q.Push(scanCandidate{path: "a"}))
+
+	pushed := make(chan bool, 1)
+	go func() { pushed <- q.Push(scanCandidate{path: "b"}) }()
+
+	select {
+	case <-pushed:
+		t.Fatal("Push should have blocked: queue of capacity 1 is already full")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	c, ok := q.Pop()
+	require.True(t, ok)
+	assert.Equal(t, "a", c.path)
+	assert.True(t, <-pushed)
+}
+
+func TestCandidateQueue_PushBlocksWhenFull(t *testing.T) {
+	q := newCandidateQueue(1)
+	require.True(t, q.Push(scanCandidate{path: "first"}))
+
+	done := make(chan struct{})
+	go func() {
+		q.Push(scanCandidate{path: "second"})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("Push should block while the queue is full")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	_, _ = q.Pop()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Push did not unblock after a Pop freed capacity")
+	}
+}
+
+func TestCandidateQueue_CloseUnblocksPushAndPop(t *testing.T) {
+	q := newCandidateQueue(1)
+	require.True(t, q.Push(scanCandidate{path: "buffered"}))
+
+	blockedPush := make(chan bool, 1)
+	go func() { blockedPush <- q.Push(scanCandidate{path: "dropped"}) }()
+
+	time.Sleep(20 * time.Millisecond)
+	q.Close()
+
+	select {
+	case ok := <-blockedPush:
+		assert.False(t, ok, "Push after Close should report false")
+	case <-time.After(time.Second):
+		t.Fatal("Close did not unblock a waiting Push")
+	}
+
+	c, ok := q.Pop()
+	require.True(t, ok, "a buffered candidate should still drain after Close")
+	assert.Equal(t, "buffered", c.path)
+
+	_, ok = q.Pop()
+	assert.False(t, ok, "Pop on a closed, drained queue should report false")
+}
+
+func TestCandidateQueue_ConcurrentProducersConsumers(t *testing.T) {
+	q := newCandidateQueue(8)
+	const n = 500
+
+	var produced sync.WaitGroup
+	produced.Add(1)
+	go func() {
+		defer produced.Done()
+		for i := 0; i < n; i++ {
+			q.Push(scanCandidate{path: string(rune(i))})
+		}
+		q.Close()
+	}()
+
+	count := 0
+	for {
+		_, ok := q.Pop()
+		if !ok {
+			break
+		}
+		count++
+	}
+	produced.Wait()
+	assert.Equal(t, n, count)
+}
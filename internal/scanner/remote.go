@@ -0,0 +1,63 @@
+package scanner
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ejagojo/SentryScan/internal/gitx/remote"
+)
+
+// scanRemote scans a remote repository without requiring a pre-existing local checkout. It
+// partially clones the repo (falling back to a full clone when the server doesn't advertise
+// blob filtering), enumerates the paths changed in opts.From..opts.To, and scans only the
+// blobs on the "to" side of that range.
+func (s *SecretScanner) scanRemote(ctx context.Context, ropts RemoteOptions, opts ScannerOptions, findings *[]Finding, mu *sync.Mutex) error {
+	fetcher, err := remote.NewPartialFetcher(ropts.URL, ropts.Filter, remote.AuthOptions{
+		SSHKeyPath:     ropts.SSHKeyPath,
+		SSHKeyPassword: ropts.SSHKeyPassword,
+		Token:          ropts.Token,
+	})
+	if err != nil {
+		return err
+	}
+	defer fetcher.Close()
+
+	from, to := ropts.From, ropts.To
+	if to == "" {
+		to = "HEAD"
+	}
+
+	changes, err := fetcher.ListChangedPaths(from, to)
+	if err != nil {
+		return err
+	}
+
+	for _, change := range changes {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if change.NewOID.IsZero() {
+			continue
+		}
+
+		r, err := fetcher.OpenBlob(change.NewOID)
+		if err != nil {
+			continue
+		}
+
+		fileFindings, err := s.ScanReader(r, SourceMeta{Path: change.Path})
+		r.Close()
+		if err != nil {
+			return err
+		}
+		if len(fileFindings) == 0 {
+			continue
+		}
+
+		mu.Lock()
+		*findings = append(*findings, fileFindings...)
+		mu.Unlock()
+	}
+
+	return nil
+}
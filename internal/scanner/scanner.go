@@ -1,6 +1,8 @@
 package scanner
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"fmt"
@@ -8,22 +10,107 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"runtime"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/ejagojo/SentryScan/internal/gitx"
 	"golang.org/x/sync/errgroup"
 )
 
+// binarySniffLen is the number of leading bytes inspected when deciding whether a blob is
+// binary, mirroring the heuristic git itself uses for diffing.
+const binarySniffLen = 8 * 1024
+
+// defaultPerFileTimeout bounds how long a single file may occupy a worker goroutine. It
+// replaces a whole-scan timeout with a per-file one, so one pathological file (a zip bomb, a
+// crafted binary) only cancels its own worker instead of starving every other file in flight.
+const defaultPerFileTimeout = 30 * time.Second
+
 // ScannerOptions defines the configuration for scanning
 type ScannerOptions struct {
 	IncludeExt  []string
 	ExcludeExt  []string
 	MaxFileSize int64
 	SkipHidden  bool
-	Threads     int
+
+	// Threads caps both the number of directory trees scanned concurrently and, per tree,
+	// the number of filesystem worker goroutines in the fan-out/fan-in pipeline (see
+	// scanFilesystem). Zero or negative falls back to SecretScanner.SetHasherCount's override,
+	// or failing that, a per-OS default (see defaultHasherCount).
+	Threads int
+
+	// PerFileTimeout bounds how long a single file may run through the detection rules
+	// before its worker gives up on it. Zero defaults to defaultPerFileTimeout.
+	PerFileTimeout time.Duration
+
 	Since       string
 	Branch      string
 	CommitRange string
+	Blame       bool
+	BlameRev    string
+
+	RespectGitignore bool
+	ExtraIgnoreFiles []string
+
+	Remote *RemoteOptions
+
+	Keyring    string
+	Signatures *SignatureOptions
+
+	// Depth limits how many commits scanGitRepo walks back from the starting rev (0 = no
+	// limit). ShallowPack is currently a no-op placeholder: go-git always materializes
+	// blobs through its object storer rather than reading packfiles directly, so there is
+	// no separate code path to opt into yet.
+	Depth       int
+	ShallowPack bool
+
+	// EntropyThreshold and MinTokenLen tune the generic-high-entropy detector (see
+	// entropy.go). Zero restores the package defaults.
+	EntropyThreshold float64
+	MinTokenLen      int
+
+	// BlacklistedStrings, BlacklistedExtensions, BlacklistedPaths, and ExcludePaths mirror
+	// the matching ScannerConfig fields (see config.go, which also expands the {sep}/
+	// {name_sep} tokens in the two path lists before they reach here).
+	BlacklistedStrings    []string
+	BlacklistedExtensions []string
+	BlacklistedPaths      []string
+	ExcludePaths          []string
+}
+
+// SignatureOptions configures the git-signature rule family applied during scanGitRepo.
+type SignatureOptions struct {
+	Require bool
+
+	// AllowedSigners restricts which signers are accepted, by committer email or signing-key
+	// fingerprint. AllowedKeyIDs does the same by short OpenPGP key ID (the form
+	// `git log --show-signature` prints). Empty means any signer that verifies against the
+	// keyring is accepted.
+	AllowedSigners []string
+	AllowedKeyIDs  []string
+
+	SeverityUnsigned string
+	SeverityBad      string
+}
+
+// RemoteOptions configures scanning a remote repository via a partial clone instead of a
+// pre-existing local checkout. See internal/gitx/remote.PartialFetcher.
+type RemoteOptions struct {
+	URL    string
+	From   string
+	To     string
+	Filter string
+
+	// SSHKeyPath, if set, authenticates the clone over SSH using the given private key file.
+	SSHKeyPath     string
+	SSHKeyPassword string
+
+	// Token authenticates the clone over HTTP(S) as a bearer/PAT-style credential, so CI can
+	// point SentryScan at a private URL without pre-cloning.
+	Token string
 }
 
 // Scanner defines the interface for all scanning operations
@@ -43,8 +130,130 @@ type SourceMeta struct {
 
 // SecretScanner implements the Scanner interface for secret detection
 type SecretScanner struct {
-	rules []Rule
-	mu    sync.RWMutex
+	rules       []Rule
+	mu          sync.RWMutex
+	blame       *blameCache
+	cache       FindingsCache
+	entropy     entropyDetector
+	hasherCount int
+
+	// maxFileSize caps how much of a single source ScanReader's streaming path (scanStreaming)
+	// reads: the limit is checked after each streamChunkSize chunk, so reading stops at the end
+	// of the chunk that crosses it and the findings so far are reported. Input that fits in one
+	// streamChunkSize read is buffered and scanned whole regardless of the limit. Zero (the
+	// default) means unbounded. This is a backstop for callers that hand ScanReader an
+	// io.Reader directly - e.g. an image layer tarball entry - bypassing the file-size skip scanFilesystem applies via
+	// ScannerOptions.MaxFileSize before a path is ever opened.
+	maxFileSize int64
+
+	// lineEngine, blobEngine, filenameEngine, and pathEngine are MatchScanner backends built
+	// from the corresponding bucket of rulePartition (see rebuildEngines). The default build
+	// wires them to re2Engine; -tags hyperscan swaps in hyperscanEngine instead.
+	lineEngine     MatchScanner
+	blobEngine     MatchScanner
+	filenameEngine MatchScanner
+	pathEngine     MatchScanner
+
+	blobRules     []Rule
+	filenameRules []Rule
+	pathRules     []Rule
+	blobOverlap   int
+}
+
+// rebuildEngines partitions s.rules into line/blob/filename/path buckets and (re)builds each
+// bucket's MatchScanner. Called once from NewScanner; there is currently no public API that
+// mutates s.rules afterwards.
+func (s *SecretScanner) rebuildEngines() {
+	p := partitionRules(s.rules)
+	s.lineEngine = newMatchScanner(p.line)
+	s.blobEngine = newMatchScanner(p.blob)
+	s.filenameEngine = newMatchScanner(p.filename)
+	s.pathEngine = newMatchScanner(p.path)
+	s.blobRules = p.blob
+	s.filenameRules = p.filename
+	s.pathRules = p.path
+	s.blobOverlap = maxPatternLen(p.blob)
+}
+
+// SetHasherCount overrides the worker-pool size scanFilesystem falls back to whenever
+// ScannerOptions.Threads is left unset (0). Passing n<=0 reverts to the per-OS default (see
+// defaultHasherCount).
+func (s *SecretScanner) SetHasherCount(n int) {
+	s.mu.Lock()
+	s.hasherCount = n
+	s.mu.Unlock()
+}
+
+// SetMaxFileSize bounds how many bytes ScanReader's streaming path reads from a single source,
+// at streamChunkSize granularity (see maxFileSize). Passing n<=0 removes the bound.
+func (s *SecretScanner) SetMaxFileSize(n int64) {
+	s.mu.Lock()
+	s.maxFileSize = n
+	s.mu.Unlock()
+}
+
+// resolveThreads picks the worker-pool size for a run: an explicit ScannerOptions.Threads
+// wins, then a SetHasherCount override, then the per-OS default.
+func (s *SecretScanner) resolveThreads(requested int) int {
+	if requested > 0 {
+		return requested
+	}
+
+	s.mu.RLock()
+	override := s.hasherCount
+	s.mu.RUnlock()
+	if override > 0 {
+		return override
+	}
+
+	return defaultHasherCount()
+}
+
+// defaultHasherCount sizes the worker pool per OS when neither ScannerOptions.Threads nor
+// SetHasherCount is set: interactive OSes get a small, laptop-friendly pool so a scan doesn't
+// compete with the foreground desktop, while server OSes get the full core count. This mirrors
+// the hasher-sizing heuristic syncthing uses for its block hashers.
+func defaultHasherCount() int {
+	switch runtime.GOOS {
+	case "windows", "darwin", "android":
+		if runtime.NumCPU() < 2 {
+			return 1
+		}
+		return 2
+	default:
+		return runtime.NumCPU()
+	}
+}
+
+// SetEntropyOptions configures the generic-high-entropy detector that complements the named
+// regex rules. A zero threshold/minTokenLen restores the package defaults (see entropy.go).
+func (s *SecretScanner) SetEntropyOptions(threshold float64, minTokenLen int) {
+	s.entropy = newEntropyDetector(threshold, minTokenLen)
+}
+
+// SetBlamer overrides the Blamer used for --blame enrichment, so tests can stub git blame
+// instead of paying for a real blame pass.
+func (s *SecretScanner) SetBlamer(b Blamer) {
+	s.blame = newBlameCache(b)
+}
+
+// FindingsCache is implemented by internal/cache.Cache; it's declared here (rather than
+// importing internal/cache directly) to avoid a dependency cycle, since the cache package
+// needs scanner.Finding and scanner.Rule for RulesDigest.
+type FindingsCache interface {
+	Lookup(blobSHA256 string) ([]Finding, bool)
+	Store(blobSHA256 string, findings []Finding) error
+}
+
+// SetCache wires a content-addressed findings cache into the scanner. When set, ScanReader
+// hashes each blob's content once and short-circuits pattern evaluation on a cache hit.
+func (s *SecretScanner) SetCache(c FindingsCache) {
+	s.cache = c
+}
+
+// Rules returns the active rule set, primarily so callers can compute a cache digest over it.
+func (s *SecretScanner) Rules() []Rule {
+	return s.rules
 }
 
 // Rule defines a detection rule
@@ -53,6 +262,65 @@ type Rule struct {
 	Description string
 	Severity    string
 	Pattern     *regexp.Regexp
+
+	// Remediation is attached to every Finding this rule produces. It is optional; rules
+	// that don't set it simply produce findings with a zero-value Remediation.
+	Remediation Remediation
+
+	// Multiline lets Pattern match across line breaks: ScanReader evaluates it against the
+	// whole blob in overlapping chunks (see scanBlob) instead of one line at a time. Ignored
+	// when PartFilename or PartPath is set, since those targets are never multi-line.
+	Multiline bool
+
+	// ContextBefore and ContextAfter extend a finding's reported snippet by that many bytes
+	// on either side of the match. Zero (the default) reports just the match itself for
+	// blob/filename/path rules, or the whole source line for ordinary body rules.
+	ContextBefore int
+	ContextAfter  int
+
+	// PartBody, PartFilename, and PartPath select what Pattern is evaluated against: file
+	// contents, the file's base name, or its full path, respectively. PartBody is the
+	// implicit default (every built-in rule leaves all three false); PartFilename and
+	// PartPath redirect Pattern away from file contents entirely.
+	PartBody     bool
+	PartFilename bool
+	PartPath     bool
+
+	// MaxMatchLen discards a match whose Value exceeds this many bytes, guarding against a
+	// pattern whose capture group can grow unbounded (e.g. a greedy base64 class) turning a
+	// single pathological input into a wall of noise - or, on backends without RE2's linear-
+	// time guarantee, runaway backtracking. Zero (the default) leaves matches unbounded.
+	MaxMatchLen int
+}
+
+// Remediation carries actionable guidance for a Finding, beyond the free-text Description.
+type Remediation struct {
+	Text string
+	URL  string
+
+	// AutoFix holds a ready-to-apply patch when one can be generated mechanically (e.g.
+	// redacting a matched secret in place). Nil means no automated fix is available, which is
+	// the common case - most findings require a human to rotate a credential, not just edit a
+	// file.
+	AutoFix *Patch
+}
+
+// Patch is a mechanically-generated fix for a Finding, suitable for an IDE quick-fix or a
+// `sentryscan fix` command to apply without a human writing the diff by hand.
+type Patch struct {
+	Description string
+	Diff        string
+}
+
+// Evidence is the supporting detail behind a Finding: the matched snippet, a stable
+// fingerprint for baseline/SARIF suppression continuity, and - for rules whose confidence
+// isn't binary (the generic high-entropy detector, chiefly) - the entropy score and a
+// human-readable confidence band that produced it.
+type Evidence struct {
+	Snippet      string
+	Fingerprint  string
+	EntropyScore float64
+	Confidence   string
 }
 
 // Finding represents a detected secret or vulnerability
@@ -67,44 +335,121 @@ type Finding struct {
 	Match       string
 	Context     string
 	Fingerprint string
+
+	// Probe is a stable rule-evaluation identifier, distinct from RuleID in spirit even
+	// though it currently mirrors it: RuleID can be remapped by config, while Probe is meant
+	// to stay stable for downstream tools that key off it across rule renames.
+	Probe       string
+	Remediation Remediation
+	Evidence    Evidence
+
+	// Git history provenance, populated by scanGitRepo. --blame mode (see enrichWithBlame) also
+	// sets Author.
+	CommitHash   string
+	Author       string
+	AuthoredAt   string
+	IntroducedIn string
+
+	// Aliases of the above under the names used by GitScanner mode / SARIF provenance.
+	// AuthorEmail is also set by --blame mode (see enrichWithBlame) so it's populated under
+	// either provenance path even though scanGitRepo itself doesn't fill it in.
+	CommitSHA   string
+	CommittedAt string
+	ParentSHA   string
+	AuthorEmail string
+
+	// Blame attribution, populated only when ScannerOptions.Blame is set. enrichWithBlame also
+	// copies these onto CommitSHA/Author/AuthorEmail/CommittedAt above, so SARIF
+	// versionControlProvenance and webhook payloads see blame-derived attribution the same way
+	// they'd see scanGitRepo's.
+	BlameCommit string
+	BlameAuthor string
+	BlameEmail  string
+	BlameDate   string
+	BlameSummary string
+
+	// LayerDigest and CreatedByInstruction are populated only by image.Scanner's layer-diff
+	// mode: the digest of the image layer the finding was found in, and the Dockerfile
+	// instruction (from the image config's history) that produced that layer.
+	LayerDigest          string
+	CreatedByInstruction string
+
+	// Violations is populated by ScannerConfig.ClassifyViolations after a scan completes. It's
+	// nil until then, meaning "not yet classified" rather than "informational" - callers that
+	// skip classification (e.g. older code paths) see every finding as a plain vulnerability.
+	// Tagged violation_context to match the JSON output field jfrog-cli-security-style
+	// consumers expect.
+	Violations *ViolationContext `json:"violation_context,omitempty"`
+}
+
+// ViolationContext records whether a finding matched a ScannerConfig watch rule and is
+// therefore a policy-breaking "violation" rather than a purely informational "vulnerability" -
+// mirroring the vulnerabilities/violations split jfrog-cli-security uses so new rules can be
+// rolled out without immediately breaking CI.
+type ViolationContext struct {
+	Violation bool   `json:"violation"`
+	Action    string `json:"action,omitempty"`
+	WatchRule string `json:"watchRule,omitempty"`
 }
 
 // NewScanner creates a new Scanner instance
 func NewScanner() *SecretScanner {
-	return &SecretScanner{
+	s := &SecretScanner{
+		entropy: newEntropyDetector(0, 0),
 		rules: []Rule{
 			{
 				ID:          "aws-access-key",
 				Description: "AWS Access Key detected",
 				Severity:    "high",
 				Pattern:     regexp.MustCompile(`(?i)aws_access_key_id\s*=\s*['"]?([A-Z0-9]{20})['"]?`),
+				Remediation: Remediation{
+					Text: "Revoke this access key in IAM and issue a new one; load credentials from an environment variable or secrets manager instead of committing them.",
+					URL:  "https://docs.aws.amazon.com/IAM/latest/UserGuide/id_credentials_access-keys.html",
+				},
 			},
 			{
 				ID:          "aws-secret-key",
 				Description: "AWS Secret Key detected",
 				Severity:    "critical",
 				Pattern:     regexp.MustCompile(`(?i)aws_secret_access_key\s*=\s*['"]?([A-Za-z0-9/+=]{40})['"]?`),
+				Remediation: Remediation{
+					Text: "Rotate the associated access key pair immediately; this secret grants full use of the key until revoked.",
+					URL:  "https://docs.aws.amazon.com/IAM/latest/UserGuide/id_credentials_access-keys.html",
+				},
 			},
 			{
 				ID:          "generic-token",
 				Description: "Generic token detected",
 				Severity:    "medium",
 				Pattern:     regexp.MustCompile(`(?i)(?:token|key|secret|password)\s*[:=]\s*['"]?([a-zA-Z0-9_\-]{32,})['"]?`),
+				Remediation: Remediation{
+					Text: "Rotate the credential and move it out of source control into a secrets manager or environment variable.",
+				},
 			},
 		},
 	}
+	s.rebuildEngines()
+	return s
 }
 
 // Run executes the scanner with the given options and paths
 func (s *SecretScanner) Run(ctx context.Context, opts ScannerOptions, paths ...string) ([]Finding, error) {
-	if len(paths) == 0 {
+	if len(paths) == 0 && opts.Remote == nil {
 		paths = []string{"."}
 	}
 
+	s.SetEntropyOptions(opts.EntropyThreshold, opts.MinTokenLen)
+
 	var findings []Finding
 	var mu sync.Mutex
 	g, ctx := errgroup.WithContext(ctx)
-	g.SetLimit(opts.Threads)
+	g.SetLimit(s.resolveThreads(opts.Threads))
+
+	if opts.Remote != nil {
+		g.Go(func() error {
+			return s.scanRemote(ctx, *opts.Remote, opts, &findings, &mu)
+		})
+	}
 
 	for _, path := range paths {
 		path := path
@@ -115,54 +460,207 @@ func (s *SecretScanner) Run(ctx context.Context, opts ScannerOptions, paths ...s
 			}
 
 			// Otherwise scan as filesystem
-			return filepath.WalkDir(path, func(path string, d os.DirEntry, err error) error {
-				if err != nil {
-					return err
-				}
+			fsFindings, err := s.scanFilesystem(ctx, path, opts)
+			if err != nil {
+				return err
+			}
 
-				if d.IsDir() {
-					if opts.SkipHidden && strings.HasPrefix(d.Name(), ".") {
-						return filepath.SkipDir
-					}
-					return nil
-				}
+			mu.Lock()
+			findings = append(findings, fsFindings...)
+			mu.Unlock()
+
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	findings = filterBlacklistedStrings(findings, opts.BlacklistedStrings)
+
+	sort.Slice(findings, func(i, j int) bool {
+		if findings[i].Path != findings[j].Path {
+			return findings[i].Path < findings[j].Path
+		}
+		return findings[i].Line < findings[j].Line
+	})
 
-				// Check file extension
-				ext := filepath.Ext(path)
-				if len(opts.IncludeExt) > 0 && !contains(opts.IncludeExt, ext) {
-					return nil
+	return findings, nil
+}
+
+// scanFilesystem walks root on a single goroutine, pushing candidate files onto a bounded,
+// slice-backed candidateQueue, and fans them out to s.resolveThreads(opts.Threads) worker
+// goroutines that apply the detection rules in parallel. The queue holds at most 4x that many
+// candidates, so memory stays flat regardless of repo size: the walker blocks once it's full
+// instead of racing ahead of the workers. Results are collected onto a channel and merged on
+// the calling goroutine once every worker has exited. Run sorts the combined findings
+// afterwards, so callers don't need to care about scheduling order here.
+func (s *SecretScanner) scanFilesystem(ctx context.Context, root string, opts ScannerOptions) ([]Finding, error) {
+	workers := s.resolveThreads(opts.Threads)
+
+	matcher := newWalkMatcher(root, opts)
+
+	type outcome struct {
+		findings []Finding
+		err      error
+	}
+
+	candidates := newCandidateQueue(4 * workers)
+	outcomes := make(chan outcome, workers)
+
+	// Closing the queue on cancellation unblocks both the walker (stuck on a full Push) and
+	// every worker (stuck on an empty Pop) so the whole pipeline drains promptly.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			candidates.Close()
+		case <-done:
+		}
+	}()
+
+	var walkErr error
+	go func() {
+		walkErr = filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			rel, relErr := filepath.Rel(root, path)
+			if relErr != nil {
+				rel = path
+			}
+
+			if d.IsDir() {
+				if opts.SkipHidden && strings.HasPrefix(d.Name(), ".") {
+					return filepath.SkipDir
 				}
-				if contains(opts.ExcludeExt, ext) {
-					return nil
+				if rel != "." && matcher.skipDir(rel) {
+					return filepath.SkipDir
 				}
+				if pathBlacklisted(path, opts.BlacklistedPaths, opts.ExcludePaths) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
 
-				// Check file size
-				if info, err := d.Info(); err == nil && opts.MaxFileSize > 0 && info.Size() > opts.MaxFileSize {
-					return nil
+			if rel != "." && matcher.skipFile(rel) {
+				return nil
+			}
+			if pathBlacklisted(path, opts.BlacklistedPaths, opts.ExcludePaths) {
+				return nil
+			}
+
+			ext := filepath.Ext(path)
+			if len(opts.IncludeExt) > 0 && !contains(opts.IncludeExt, ext) {
+				return nil
+			}
+			if contains(opts.ExcludeExt, ext) || contains(opts.BlacklistedExtensions, ext) {
+				return nil
+			}
+
+			if info, err := d.Info(); err == nil && opts.MaxFileSize > 0 && info.Size() > opts.MaxFileSize {
+				return nil
+			}
+
+			if !candidates.Push(scanCandidate{path: path, rel: rel}) {
+				return ctx.Err()
+			}
+			return nil
+		})
+		candidates.Close()
+	}()
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for {
+				c, ok := candidates.Pop()
+				if !ok {
+					return
 				}
 
-				// Scan the file
-				fileFindings, err := s.ScanFile(path)
+				fileFindings, err := s.scanFileWithTimeout(ctx, c.path, opts)
 				if err != nil {
-					return err
+					outcomes <- outcome{err: err}
+					continue
 				}
 
-				mu.Lock()
-				findings = append(findings, fileFindings...)
-				mu.Unlock()
+				if opts.Blame && len(fileFindings) > 0 && isGitRepo(root) {
+					s.mu.Lock()
+					if s.blame == nil {
+						s.blame = newBlameCache(nil)
+					}
+					s.mu.Unlock()
+					enrichWithBlame(s.blame, root, c.rel, opts.BlameRev, fileFindings)
+				}
 
-				return nil
-			})
-		})
+				outcomes <- outcome{findings: fileFindings}
+			}
+		}()
 	}
 
-	if err := g.Wait(); err != nil {
-		return nil, err
+	go func() {
+		wg.Wait()
+		close(outcomes)
+	}()
+
+	var findings []Finding
+	var firstErr error
+	for o := range outcomes {
+		if o.err != nil {
+			if firstErr == nil {
+				firstErr = o.err
+			}
+			continue
+		}
+		findings = append(findings, o.findings...)
+	}
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if walkErr != nil && walkErr != context.Canceled {
+		return nil, walkErr
 	}
 
 	return findings, nil
 }
 
+// scanFileWithTimeout runs ScanFile under a context.WithTimeout bound to a single file, so a
+// zip bomb or other pathological input only ties up the worker that drew it instead of the
+// whole-scan deadline used previously.
+func (s *SecretScanner) scanFileWithTimeout(ctx context.Context, path string, opts ScannerOptions) ([]Finding, error) {
+	timeout := opts.PerFileTimeout
+	if timeout <= 0 {
+		timeout = defaultPerFileTimeout
+	}
+
+	fctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type result struct {
+		findings []Finding
+		err      error
+	}
+	done := make(chan result, 1)
+	go func() {
+		findings, err := s.ScanFile(path)
+		done <- result{findings: findings, err: err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.findings, r.err
+	case <-fctx.Done():
+		return nil, fmt.Errorf("scanning %s: %w", path, fctx.Err())
+	}
+}
+
 // ScanFile scans a file for secrets
 func (s *SecretScanner) ScanFile(path string) ([]Finding, error) {
 	file, err := os.Open(path)
@@ -174,42 +672,300 @@ func (s *SecretScanner) ScanFile(path string) ([]Finding, error) {
 	return s.ScanReader(file, SourceMeta{Path: path})
 }
 
-// ScanReader scans content from an io.Reader
+// streamChunkSize is how much of an io.Reader ScanReader's streaming path (scanStreaming)
+// pulls into memory per read, once the input has proven too large to buffer in full.
+const streamChunkSize = 1 << 20 // 1 MiB
+
+// streamOverlap is how many bytes of each streamed chunk are carried into the next one, so a
+// match straddling a chunk boundary (a base64 blob, a PEM key, a long token) is still found
+// whole in at least one window.
+const streamOverlap = 4 << 10 // 4 KiB
+
+// ScanReader scans content from an io.Reader. Input small enough to fit in one streamChunkSize
+// read is buffered in full, exactly as before, so the content-addressed cache (which needs a
+// whole-blob hash up front) still short-circuits repeat scans of ordinary source files. Larger
+// input - multi-gigabyte files, container layer tarballs - is handed to scanStreaming instead,
+// which never holds more than a couple of chunks in memory at once.
 func (s *SecretScanner) ScanReader(r io.Reader, meta SourceMeta) ([]Finding, error) {
-	content, err := io.ReadAll(r)
-	if err != nil {
+	br := bufio.NewReaderSize(r, streamChunkSize+1)
+	peek, err := br.Peek(streamChunkSize + 1)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
 		return nil, err
 	}
 
 	var findings []Finding
-	lines := strings.Split(string(content), "\n")
-
-	for i, line := range lines {
-		for _, rule := range s.rules {
-			matches := rule.Pattern.FindAllStringSubmatch(line, -1)
-			for _, match := range matches {
-				if len(match) > 1 {
-					finding := Finding{
-						Type:        "secret",
-						RuleID:      rule.ID,
-						Description: rule.Description,
-						Severity:    rule.Severity,
-						Path:        meta.Path,
-						Line:        i + 1,
-						Column:      strings.Index(line, match[1]) + 1,
-						Match:       match[1],
-						Context:     line,
-						Fingerprint: fmt.Sprintf("%x", sha256.Sum256([]byte(match[1]))),
-					}
-					findings = append(findings, finding)
+	var blobHash string
+
+	if len(peek) <= streamChunkSize {
+		content, err := io.ReadAll(br)
+		if err != nil {
+			return nil, err
+		}
+
+		if s.cache != nil {
+			sum := sha256.Sum256(content)
+			blobHash = fmt.Sprintf("%x", sum)
+			if cached, ok := s.cache.Lookup(blobHash); ok {
+				cachedFindings := make([]Finding, len(cached))
+				for i, f := range cached {
+					f.Path = meta.Path
+					cachedFindings[i] = f
 				}
+				return cachedFindings, nil
 			}
 		}
+
+		findings = s.scanBuffered(content, meta)
+	} else {
+		streamed, err := s.scanStreaming(br, peek, meta)
+		if err != nil {
+			return nil, err
+		}
+		findings = streamed
+	}
+	if len(s.filenameRules) > 0 {
+		findings = append(findings, s.scanNonBody(s.filenameEngine, filepath.Base(meta.Path), meta)...)
+	}
+	if len(s.pathRules) > 0 {
+		findings = append(findings, s.scanNonBody(s.pathEngine, meta.Path, meta)...)
+	}
+
+	// blobHash is only ever populated on the buffered path (see above): scanStreaming never
+	// sees the whole blob at once, so there's no single hash to key a cache entry on.
+	if s.cache != nil && blobHash != "" {
+		_ = s.cache.Store(blobHash, findings)
 	}
 
 	return findings, nil
 }
 
+// scanBuffered runs every content-based rule family (line, entropy, blob) against a fully
+// buffered blob. This is ScanReader's original, simpler path, still used whenever the input is
+// small enough to fit in one streamChunkSize read.
+func (s *SecretScanner) scanBuffered(content []byte, meta SourceMeta) []Finding {
+	var findings []Finding
+	seenTokens := newTokenBloom()
+
+	for i, line := range bytes.Split(content, []byte("\n")) {
+		findings = append(findings, s.lineFindings(line, i+1, meta, seenTokens)...)
+	}
+
+	if len(s.blobRules) > 0 {
+		findings = append(findings, s.scanBlob(content, meta)...)
+	}
+
+	return findings
+}
+
+// lineFindings runs the line-mode rule engine and the entropy detector against a single line,
+// mirroring the per-line body of the original ScanReader loop so scanBuffered and
+// scanStreaming produce identical findings for the same input.
+func (s *SecretScanner) lineFindings(line []byte, lineNo int, meta SourceMeta, seenTokens *tokenBloom) []Finding {
+	var findings []Finding
+	var namedMatches []string
+
+	for _, m := range s.lineEngine.FindAll(line, 0) {
+		if !withinMaxMatchLen(m) {
+			continue
+		}
+		column := m.Start + 1
+		snippet := string(line)
+		if m.Rule.ContextBefore > 0 || m.Rule.ContextAfter > 0 {
+			snippet = contextSnippet(line, m.Start, m.End, m.Rule.ContextBefore, m.Rule.ContextAfter)
+		}
+
+		findings = append(findings, bodyFinding(m, meta, lineNo, column, snippet))
+		namedMatches = append(namedMatches, m.Value)
+	}
+
+	for _, f := range s.entropy.scanLine(string(line), lineNo, meta.Path, seenTokens) {
+		if contains(namedMatches, f.Match) {
+			continue
+		}
+		findings = append(findings, f)
+	}
+
+	return findings
+}
+
+// scanStreaming runs the same rule families as scanBuffered, but over br in streamChunkSize
+// reads instead of a single in-memory blob, so memory stays bounded regardless of input size.
+// peeked is the first streamChunkSize+1 bytes ScanReader already buffered while deciding this
+// was the right path; it's consumed as the start of the stream, not read again.
+//
+// Line-mode rules and the entropy detector see each line exactly once: lineCarry holds the
+// previous chunk's unterminated trailing line, prepended to the next chunk before splitting.
+// Blob (multiline) rules instead see a fixed streamOverlap-byte tail of raw bytes prepended to
+// each chunk, with matches deduplicated by absolute offset in blobSeen, since a multiline match
+// can start and end anywhere in that window regardless of line boundaries.
+func (s *SecretScanner) scanStreaming(br *bufio.Reader, peeked []byte, meta SourceMeta) ([]Finding, error) {
+	s.mu.RLock()
+	maxFileSize := s.maxFileSize
+	s.mu.RUnlock()
+
+	var findings []Finding
+	seenTokens := newTokenBloom()
+	blobSeen := make(map[string]struct{})
+
+	var lineCarry []byte
+	var blobCarry []byte
+	var newlinesBeforeCarry int64
+	lineNo := 0
+	var streamPos int64
+
+	buf := make([]byte, streamChunkSize)
+	first := true
+
+	for {
+		var chunk []byte
+		var eof bool
+
+		if first {
+			chunk = peeked
+			first = false
+			if _, err := br.Discard(len(peeked)); err != nil && err != io.EOF {
+				return nil, err
+			}
+		} else {
+			n, err := io.ReadFull(br, buf)
+			chunk = buf[:n]
+			switch {
+			case err == nil:
+			case err == io.EOF, err == io.ErrUnexpectedEOF:
+				eof = true
+			default:
+				return nil, err
+			}
+		}
+
+		if len(chunk) == 0 && len(lineCarry) == 0 && len(blobCarry) == 0 {
+			break
+		}
+
+		streamPos += int64(len(chunk))
+		hitMaxSize := maxFileSize > 0 && streamPos > maxFileSize
+
+		// Line-mode rules and entropy: split carry+chunk into lines, process every complete
+		// one, and carry the unterminated remainder (if any) into the next iteration.
+		lineWindow := append(lineCarry, chunk...)
+		lines := bytes.Split(lineWindow, []byte("\n"))
+		complete := lines
+		lineCarry = nil
+		if !eof && len(lineWindow) > 0 {
+			// The last element is an unterminated partial line (empty when the window ends in
+			// '\n'); hold it for next time rather than counting it as a line of its own.
+			complete = lines[:len(lines)-1]
+			lineCarry = append([]byte(nil), lines[len(lines)-1]...)
+		}
+		for _, line := range complete {
+			lineNo++
+			findings = append(findings, s.lineFindings(line, lineNo, meta, seenTokens)...)
+		}
+
+		// Blob (multiline) rules: scan the carried tail plus this chunk as one window.
+		if len(s.blobRules) > 0 {
+			window := append(append([]byte(nil), blobCarry...), chunk...)
+			windowStart := streamPos - int64(len(chunk)) - int64(len(blobCarry))
+
+			for _, m := range matchWindow(s.blobEngine, window, int(windowStart), blobSeen) {
+				localStart := m.Start - int(windowStart)
+				localEnd := m.End - int(windowStart)
+				line := newlinesBeforeCarry + int64(bytes.Count(window[:localStart], []byte("\n"))) + 1
+				column := localStart + 1
+				if idx := bytes.LastIndexByte(window[:localStart], '\n'); idx >= 0 {
+					column = localStart - idx
+				}
+				snippet := contextSnippet(window, localStart, localEnd, m.Rule.ContextBefore, m.Rule.ContextAfter)
+				findings = append(findings, bodyFinding(m, meta, int(line), column, snippet))
+			}
+
+			retainLen := streamOverlap
+			if retainLen > len(window) {
+				retainLen = len(window)
+			}
+			newlinesBeforeCarry += int64(bytes.Count(window[:len(window)-retainLen], []byte("\n")))
+			blobCarry = append([]byte(nil), window[len(window)-retainLen:]...)
+		}
+
+		if eof || hitMaxSize {
+			break
+		}
+	}
+
+	return findings, nil
+}
+
+// bodyFinding builds the Finding for a single body-rule match, shared by the line-mode,
+// buffered-blob, and streaming-blob paths so all three stay in sync.
+func bodyFinding(m RuleMatch, meta SourceMeta, line, column int, snippet string) Finding {
+	fingerprint := fmt.Sprintf("%x", sha256.Sum256([]byte(m.Value)))
+
+	return Finding{
+		Type:        "secret",
+		RuleID:      m.Rule.ID,
+		Description: m.Rule.Description,
+		Severity:    m.Rule.Severity,
+		Path:        meta.Path,
+		Line:        line,
+		Column:      column,
+		Match:       m.Value,
+		Context:     snippet,
+		Fingerprint: fingerprint,
+		Probe:       m.Rule.ID,
+		Remediation: m.Rule.Remediation,
+		Evidence: Evidence{
+			Snippet:     snippet,
+			Fingerprint: fingerprint,
+			Confidence:  "high",
+		},
+	}
+}
+
+// scanBlob evaluates multiline (whole-blob) body rules against content, streaming it through
+// s.blobEngine in defaultChunkSize windows that overlap by s.blobOverlap bytes, so a match
+// straddling a chunk boundary isn't missed.
+func (s *SecretScanner) scanBlob(content []byte, meta SourceMeta) []Finding {
+	var findings []Finding
+	for _, m := range chunkedFindAll(s.blobEngine, content, defaultChunkSize, s.blobOverlap) {
+		line, column := lineColFromOffset(content, m.Start)
+		snippet := contextSnippet(content, m.Start, m.End, m.Rule.ContextBefore, m.Rule.ContextAfter)
+
+		findings = append(findings, bodyFinding(m, meta, line, column, snippet))
+	}
+	return findings
+}
+
+// scanNonBody evaluates filename- or path-targeting rules against value (the file's base name
+// or full path, never its contents). Findings carry no line number since there isn't one.
+func (s *SecretScanner) scanNonBody(engine MatchScanner, value string, meta SourceMeta) []Finding {
+	var findings []Finding
+	for _, m := range engine.FindAll([]byte(value), 0) {
+		column := m.Start + 1
+		snippet := contextSnippet([]byte(value), m.Start, m.End, m.Rule.ContextBefore, m.Rule.ContextAfter)
+		fingerprint := fmt.Sprintf("%x", sha256.Sum256([]byte(m.Value)))
+
+		findings = append(findings, Finding{
+			Type:        "secret",
+			RuleID:      m.Rule.ID,
+			Description: m.Rule.Description,
+			Severity:    m.Rule.Severity,
+			Path:        meta.Path,
+			Column:      column,
+			Match:       m.Value,
+			Context:     snippet,
+			Fingerprint: fingerprint,
+			Probe:       m.Rule.ID,
+			Remediation: m.Rule.Remediation,
+			Evidence: Evidence{
+				Snippet:     snippet,
+				Fingerprint: fingerprint,
+				Confidence:  "high",
+			},
+		})
+	}
+	return findings
+}
+
 // Helper functions
 func isGitRepo(path string) bool {
 	_, err := os.Stat(filepath.Join(path, ".git"))
@@ -225,8 +981,175 @@ func contains(slice []string, item string) bool {
 	return false
 }
 
-// scanGitRepo scans a git repository for secrets
+// pathBlacklisted reports whether path's absolute form contains any substring from
+// blacklisted or exclude (the latter is just a user-supplied extension of the former). Both
+// lists are expected pre-expanded (see expandPathTokens in config.go), so this is a plain
+// substring scan. Falls back to the unresolved path if filepath.Abs fails.
+func pathBlacklisted(path string, blacklisted, exclude []string) bool {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		abs = path
+	}
+	for _, p := range blacklisted {
+		if p != "" && strings.Contains(abs, p) {
+			return true
+		}
+	}
+	for _, p := range exclude {
+		if p != "" && strings.Contains(abs, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterBlacklistedStrings drops any finding whose matched snippet contains one of
+// blacklisted (case-sensitive), letting config.BlacklistedStrings suppress recurring false
+// positives without touching the rule set.
+func filterBlacklistedStrings(findings []Finding, blacklisted []string) []Finding {
+	if len(blacklisted) == 0 {
+		return findings
+	}
+
+	kept := findings[:0]
+	for _, f := range findings {
+		blocked := false
+		for _, b := range blacklisted {
+			if b != "" && strings.Contains(f.Match, b) {
+				blocked = true
+				break
+			}
+		}
+		if !blocked {
+			kept = append(kept, f)
+		}
+	}
+	return kept
+}
+
+// scanGitRepo walks every commit reachable from opts.Branch (or CommitRange/Since) and
+// runs the rule set against each blob, rather than only the current worktree. Blobs are
+// deduplicated by OID within a single run so identical file content introduced across many
+// commits is only scanned once, and oversized or binary blobs are skipped before their
+// contents are ever read.
 func (s *SecretScanner) scanGitRepo(ctx context.Context, path string, opts ScannerOptions, findings *[]Finding, mu *sync.Mutex) error {
-	// TODO: Implement git repository scanning
-	return nil
+	rev := opts.Branch
+	since := opts.Since
+	if opts.CommitRange != "" {
+		parts := strings.SplitN(opts.CommitRange, "..", 2)
+		if len(parts) == 2 {
+			since, rev = parts[0], parts[1]
+		}
+	}
+
+	var seen sync.Map      // blob OID -> struct{}
+	var seenCommit sync.Map // commit hash -> struct{}
+
+	return gitx.WalkHistory(path, rev, since, opts.Depth, func(cb gitx.CommitBlob) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if opts.Signatures != nil {
+			if _, dup := seenCommit.LoadOrStore(cb.Commit.Hash.String(), struct{}{}); !dup {
+				if f := s.checkCommitSignature(path, cb.Commit, opts); f != nil {
+					mu.Lock()
+					*findings = append(*findings, *f)
+					mu.Unlock()
+				}
+			}
+		}
+
+		ext := filepath.Ext(cb.Path)
+		if len(opts.IncludeExt) > 0 && !contains(opts.IncludeExt, ext) {
+			return nil
+		}
+		if contains(opts.ExcludeExt, ext) {
+			return nil
+		}
+		if opts.MaxFileSize > 0 && cb.Blob.Size > opts.MaxFileSize {
+			return nil
+		}
+
+		oid := cb.Blob.Hash.String()
+		if _, dup := seen.LoadOrStore(oid, struct{}{}); dup {
+			return nil
+		}
+
+		r, err := cb.Blob.Reader()
+		if err != nil {
+			return nil
+		}
+		defer r.Close()
+
+		isBinary, head, err := sniffBinary(r)
+		if err != nil {
+			return nil
+		}
+		if isBinary {
+			return nil
+		}
+
+		blobFindings, err := s.ScanReader(io.MultiReader(bytes.NewReader(head), r), SourceMeta{Path: cb.Path})
+		if err != nil {
+			return err
+		}
+		if len(blobFindings) == 0 {
+			return nil
+		}
+
+		introduced := cb.Commit.Hash
+		for i := range blobFindings {
+			match := blobFindings[i].Match
+			in, err := gitx.IntroducedIn(cb.Commit, cb.Path, func(rd io.Reader) (bool, error) {
+				content, err := io.ReadAll(rd)
+				if err != nil {
+					return false, err
+				}
+				return bytes.Contains(content, []byte(match)), nil
+			})
+			if err == nil {
+				introduced = in
+			}
+
+			blobFindings[i].CommitHash = cb.Commit.Hash.String()
+			blobFindings[i].Author = fmt.Sprintf("%s <%s>", cb.Commit.Author.Name, cb.Commit.Author.Email)
+			blobFindings[i].AuthoredAt = cb.Commit.Author.When.Format("2006-01-02T15:04:05Z07:00")
+			blobFindings[i].IntroducedIn = introduced.String()
+
+			blobFindings[i].CommitSHA = blobFindings[i].CommitHash
+			blobFindings[i].CommittedAt = cb.Commit.Committer.When.Format("2006-01-02T15:04:05Z07:00")
+			if parents := cb.Commit.ParentHashes; len(parents) > 0 {
+				blobFindings[i].ParentSHA = parents[0].String()
+			}
+		}
+
+		mu.Lock()
+		*findings = append(*findings, blobFindings...)
+		mu.Unlock()
+		return nil
+	})
+}
+
+// sniffBinary reads up to binarySniffLen bytes from r and returns whether the blob looks
+// binary (more than 1% null bytes, matching git's own heuristic), along with the bytes it
+// consumed so the caller can still scan them.
+func sniffBinary(r io.Reader) (bool, []byte, error) {
+	head := make([]byte, binarySniffLen)
+	n, err := io.ReadFull(r, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return false, nil, err
+	}
+	head = head[:n]
+
+	var nulls int
+	for _, b := range head {
+		if b == 0 {
+			nulls++
+		}
+	}
+	if n > 0 && float64(nulls)/float64(n) > 0.01 {
+		return true, head, nil
+	}
+	return false, head, nil
 }
@@ -0,0 +1,33 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// BenchmarkScanner_Run measures the fan-out/fan-in filesystem pipeline in scanFilesystem
+// across a directory of many small files, which is the shape TestConcurrencySaturation-style
+// workloads exercise.
+func BenchmarkScanner_Run(b *testing.B) {
+	dir := b.TempDir()
+	for i := 0; i < 500; i++ {
+		path := filepath.Join(dir, fmt.Sprintf("file-%d.txt", i))
+		content := fmt.Sprintf("password = \"secret123456789012345678901234567890-%d\"", i)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			b.Fatalf("failed to create test file: %v", err)
+		}
+	}
+
+	s := NewScanner()
+	opts := ScannerOptions{Threads: 4}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := s.Run(context.Background(), opts, dir); err != nil {
+			b.Fatalf("Run failed: %v", err)
+		}
+	}
+}
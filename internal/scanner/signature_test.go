@@ -0,0 +1,132 @@
+package scanner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// makeSignatureTestRepo creates a single-commit, unsigned repository for exercising
+// checkCommitSignature end-to-end.
+func makeSignatureTestRepo(t *testing.T) (string, *object.Commit) {
+	t.Helper()
+	dir := t.TempDir()
+
+	repo, err := git.PlainInit(dir, false)
+	require.NoError(t, err)
+
+	wt, err := repo.Worktree()
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("content"), 0644))
+	_, err = wt.Add("a.txt")
+	require.NoError(t, err)
+
+	hash, err := wt.Commit("initial", &git.CommitOptions{
+		Author: &object.Signature{Name: "Dev", Email: "dev@example.com"},
+	})
+	require.NoError(t, err)
+
+	commit, err := repo.CommitObject(hash)
+	require.NoError(t, err)
+	return dir, commit
+}
+
+// testPublicKey is a throwaway RSA key generated solely for this test; it signs nothing and
+// has no corresponding private key in this repo.
+const testPublicKey = `-----BEGIN PGP PUBLIC KEY BLOCK-----
+
+mQENBGpp8loBCADFPsUD6PmKux8G5WUxdL71lCRv1JzG5QCbu48bfQpr1Vc6MH+R
+rb8XX4OC9/1nUItBC8kxwfZX0iSSPkgr+NUw0ZYWgQaHYD/v/bK1L1ZhksNammC+
+TFZ5E7Iko5aQ8jmsJTJI9Qsyv07LLpuVIpB7afwbNbKjnOIk2+ySVcaiwR2Nb0Gq
+UzdfB/7qQs08h5Ho8Jw5HLZq1H7WRby011PvwfqCnxFCOdOVV75tYhiSLf1wruO4
+nmBPMOdnIspRHryGttN8LMdZqUEuzx4YLvuynQxjGEX7fV8myc8+DeLWXRTgm0qQ
+j46lgCLLshcB92bagfit5xAdg6Qme/v7qkYpABEBAAG0HlRlc3QgU2lnbmVyIDx0
+ZXN0QGV4YW1wbGUuY29tPokBTgQTAQoAOBYhBOJunTdQPeca6AFhR2dvg9KjxCs8
+BQJqafJaAhsDBQsJCAcCBhUKCQgLAgQWAgMBAh4BAheAAAoJEGdvg9KjxCs8N/YH
+/0rMt8GwKTeOgneCnx17j71wtd5liH4mLDXzLVo7u4xEaPvdU1B2muoFaWX5VPyg
+ux3xrsrHSeYwWU/TkCrSoXbNRVeHX01INL6ozqvF4K/F+5oIE2h+VU1/lq739aue
+URwg5l3whqPWTFUTe8q/DqZh/N6w4gYK9wBQ+cXzBHEI1jSa2axscYuvlqtdfbTO
+o4CtD0t3KG0wpDP1JxNmY4wt843jqLaANx4oxchVp9ut0qqvLOHyXT3whI12K7bc
+xL2a37JgXAyIy61uB2jWwD86YlQmPyHrfQEIN8YgM3CByu/4iy5KGW+zRa7VwV3V
++UsgosSlfisdMJNJx8KZ6FU=
+=hWpZ
+-----END PGP PUBLIC KEY BLOCK-----`
+
+func loadTestEntity(t *testing.T) *openpgp.Entity {
+	t.Helper()
+	keyring, err := openpgp.ReadArmoredKeyRing(strings.NewReader(testPublicKey))
+	require.NoError(t, err)
+	require.Len(t, keyring, 1)
+	return keyring[0]
+}
+
+func TestIsAllowedSigner_ByEmail(t *testing.T) {
+	commit := &object.Commit{Author: object.Signature{Email: "dev@example.com"}}
+
+	assert.True(t, isAllowedSigner(commit, nil, []string{"dev@example.com"}, nil))
+	assert.False(t, isAllowedSigner(commit, nil, []string{"other@example.com"}, nil))
+}
+
+func TestIsAllowedSigner_NilEntityFallsThrough(t *testing.T) {
+	commit := &object.Commit{Author: object.Signature{Email: "dev@example.com"}}
+
+	assert.False(t, isAllowedSigner(commit, nil, []string{"other@example.com"}, []string{"DEADBEEF"}))
+}
+
+func TestIsAllowedSigner_ByKeyIDAndFingerprint(t *testing.T) {
+	entity := loadTestEntity(t)
+	commit := &object.Commit{Author: object.Signature{Email: "someone-else@example.com"}}
+
+	keyID := entity.PrimaryKey.KeyIdString()
+	fingerprint := fmt.Sprintf("%X", entity.PrimaryKey.Fingerprint)
+
+	assert.True(t, isAllowedSigner(commit, entity, nil, []string{keyID}), "should match by short key ID")
+	assert.True(t, isAllowedSigner(commit, entity, []string{fingerprint}, nil), "should match by fingerprint via AllowedSigners")
+	assert.False(t, isAllowedSigner(commit, entity, []string{"not-a-match"}, []string{"not-a-match"}))
+}
+
+func TestCheckCommitSignature_UnsignedRequired(t *testing.T) {
+	s := NewScanner()
+	dir, commit := makeSignatureTestRepo(t)
+
+	finding := s.checkCommitSignature(dir, commit, ScannerOptions{
+		Signatures: &SignatureOptions{Require: true},
+	})
+
+	require.NotNil(t, finding)
+	assert.Equal(t, "unsigned-commit", finding.RuleID)
+	assert.Equal(t, "medium", finding.Severity)
+	assert.Equal(t, commit.Hash.String(), finding.CommitHash)
+}
+
+func TestCheckCommitSignature_UnsignedNotRequired(t *testing.T) {
+	s := NewScanner()
+	dir, commit := makeSignatureTestRepo(t)
+
+	finding := s.checkCommitSignature(dir, commit, ScannerOptions{
+		Signatures: &SignatureOptions{Require: false},
+	})
+
+	assert.Nil(t, finding)
+}
+
+func TestCheckCommitSignature_CustomSeverity(t *testing.T) {
+	s := NewScanner()
+	dir, commit := makeSignatureTestRepo(t)
+
+	finding := s.checkCommitSignature(dir, commit, ScannerOptions{
+		Signatures: &SignatureOptions{Require: true, SeverityUnsigned: "high"},
+	})
+
+	require.NotNil(t, finding)
+	assert.Equal(t, "high", finding.Severity)
+}
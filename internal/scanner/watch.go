@@ -0,0 +1,74 @@
+package scanner
+
+import "path/filepath"
+
+// WatchRule marks findings matching all of RuleIDGlob, PathGlob, and MinSeverity as
+// policy-breaking "violations" rather than merely informational "vulnerabilities", mirroring
+// the vulnerabilities/violations split jfrog-cli-security uses for gradual policy rollout.
+// Action controls what a match should do: "fail" makes it count toward a non-zero exit code,
+// "warn" surfaces it without affecting the exit code.
+type WatchRule struct {
+	RuleIDGlob  string `yaml:"rule_id_glob"`
+	PathGlob    string `yaml:"path_glob"`
+	MinSeverity string `yaml:"min_severity"`
+	Action      string `yaml:"action"`
+}
+
+// severityRank orders severities from least to most severe, for MinSeverity comparisons.
+var severityRank = map[string]int{
+	"low":      1,
+	"medium":   2,
+	"high":     3,
+	"critical": 4,
+}
+
+// meetsMinSeverity reports whether severity is at least as severe as min. An empty min always
+// passes, so a watch without MinSeverity matches any severity. An unrecognized severity ranks
+// below every known one and fails any recognized min; an unrecognized min is passed by any
+// severity.
+func meetsMinSeverity(severity, min string) bool {
+	if min == "" {
+		return true
+	}
+	return severityRank[severity] >= severityRank[min]
+}
+
+// matches reports whether w applies to finding: RuleIDGlob and PathGlob are filepath.Match
+// globs (empty matches anything), and the finding's severity must meet MinSeverity.
+func (w WatchRule) matches(finding Finding) bool {
+	if w.RuleIDGlob != "" {
+		if ok, err := filepath.Match(w.RuleIDGlob, finding.RuleID); err != nil || !ok {
+			return false
+		}
+	}
+	if w.PathGlob != "" {
+		if ok, err := filepath.Match(w.PathGlob, finding.Path); err != nil || !ok {
+			return false
+		}
+	}
+	return meetsMinSeverity(finding.Severity, w.MinSeverity)
+}
+
+// ClassifyViolations annotates each finding in findings with a ViolationContext: a finding is a
+// violation if the first Watches entry that matches it has Action "fail". A match with Action
+// "warn" is flagged but doesn't count as a violation. Findings matching no watch are left as
+// informational vulnerabilities (Violation: false).
+func (c *ScannerConfig) ClassifyViolations(findings []Finding) {
+	for i := range findings {
+		findings[i].Violations = c.classify(findings[i])
+	}
+}
+
+func (c *ScannerConfig) classify(finding Finding) *ViolationContext {
+	for _, w := range c.Watches {
+		if !w.matches(finding) {
+			continue
+		}
+		return &ViolationContext{
+			Violation: w.Action == "fail",
+			Action:    w.Action,
+			WatchRule: w.RuleIDGlob,
+		}
+	}
+	return &ViolationContext{Violation: false}
+}
@@ -0,0 +1,81 @@
+package scanner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeetsMinSeverity(t *testing.T) {
+	assert.True(t, meetsMinSeverity("low", ""), "empty min always passes")
+	assert.True(t, meetsMinSeverity("high", "medium"))
+	assert.False(t, meetsMinSeverity("low", "medium"))
+	assert.True(t, meetsMinSeverity("critical", "critical"))
+	assert.False(t, meetsMinSeverity("unrecognized", "medium"), "an unranked severity never meets a minimum")
+}
+
+func TestWatchRule_Matches(t *testing.T) {
+	finding := Finding{RuleID: "aws-access-key", Path: "src/config.go", Severity: "high"}
+
+	assert.True(t, WatchRule{RuleIDGlob: "aws-*"}.matches(finding))
+	assert.False(t, WatchRule{RuleIDGlob: "gcp-*"}.matches(finding))
+	assert.True(t, WatchRule{PathGlob: "src/*"}.matches(finding))
+	assert.False(t, WatchRule{PathGlob: "vendor/*"}.matches(finding))
+	assert.True(t, WatchRule{MinSeverity: "medium"}.matches(finding))
+	assert.False(t, WatchRule{MinSeverity: "critical"}.matches(finding))
+	assert.True(t, WatchRule{}.matches(finding), "a watch with no filters matches anything")
+}
+
+func TestWatchRule_Matches_InvalidGlobNeverMatches(t *testing.T) {
+	finding := Finding{RuleID: "aws-access-key", Path: "a.txt", Severity: "high"}
+	assert.False(t, WatchRule{RuleIDGlob: "["}.matches(finding))
+}
+
+func TestScannerConfig_ClassifyViolations_NoWatchesIsInformational(t *testing.T) {
+	c := &ScannerConfig{}
+	findings := []Finding{{RuleID: "x", Severity: "high"}}
+
+	c.ClassifyViolations(findings)
+
+	v := findings[0].Violations
+	assert.NotNil(t, v)
+	assert.False(t, v.Violation)
+}
+
+func TestScannerConfig_ClassifyViolations_FailAction(t *testing.T) {
+	c := &ScannerConfig{Watches: []WatchRule{{RuleIDGlob: "aws-*", Action: "fail"}}}
+	findings := []Finding{{RuleID: "aws-access-key", Severity: "high"}}
+
+	c.ClassifyViolations(findings)
+
+	v := findings[0].Violations
+	assert.NotNil(t, v)
+	assert.True(t, v.Violation)
+	assert.Equal(t, "fail", v.Action)
+	assert.Equal(t, "aws-*", v.WatchRule)
+}
+
+func TestScannerConfig_ClassifyViolations_WarnActionIsNotAViolation(t *testing.T) {
+	c := &ScannerConfig{Watches: []WatchRule{{RuleIDGlob: "aws-*", Action: "warn"}}}
+	findings := []Finding{{RuleID: "aws-access-key", Severity: "high"}}
+
+	c.ClassifyViolations(findings)
+
+	v := findings[0].Violations
+	assert.False(t, v.Violation)
+	assert.Equal(t, "warn", v.Action)
+}
+
+func TestScannerConfig_ClassifyViolations_FirstMatchingWatchWins(t *testing.T) {
+	c := &ScannerConfig{Watches: []WatchRule{
+		{RuleIDGlob: "aws-*", Action: "warn"},
+		{RuleIDGlob: "aws-*", Action: "fail"},
+	}}
+	findings := []Finding{{RuleID: "aws-access-key", Severity: "high"}}
+
+	c.ClassifyViolations(findings)
+
+	v := findings[0].Violations
+	assert.False(t, v.Violation)
+	assert.Equal(t, "warn", v.Action)
+}
@@ -10,6 +10,31 @@ import (
 // Config represents the SentryScan configuration
 type Config struct {
 	Rules []RuleConfig `yaml:"rules"`
+
+	// SecurityPolicy configures the internal/checks/policy SECURITY.md rubric check.
+	SecurityPolicy *SecurityPolicyConfig `yaml:"security_policy,omitempty"`
+}
+
+// SecurityPolicyConfig configures the internal/checks/policy check: where to look for a
+// security policy file and which rubric checks to grade it against.
+type SecurityPolicyConfig struct {
+	// Paths lists additional repo-relative candidate paths to check, layered on top of the
+	// check's built-in defaults (SECURITY.md, .github/SECURITY.md, docs/security.md,
+	// .gitlab/SECURITY.md).
+	Paths []string `yaml:"paths,omitempty"`
+
+	// Rubric adds user-supplied regex checks, each graded against the policy file's contents
+	// independently of the built-in contact/timeline/supported-versions/length checks.
+	Rubric []RubricCheck `yaml:"rubric,omitempty"`
+}
+
+// RubricCheck is a single regex-based rule in a SecurityPolicyConfig.Rubric: when Pattern
+// fails to match the policy file's contents, a Finding is emitted with RuleID ID.
+type RubricCheck struct {
+	ID          string `yaml:"id"`
+	Pattern     string `yaml:"pattern"`
+	Description string `yaml:"description"`
+	Severity    string `yaml:"severity"`
 }
 
 // RuleConfig defines a rule in the configuration
@@ -18,6 +43,22 @@ type RuleConfig struct {
 	Description string `yaml:"description"`
 	Severity    string `yaml:"severity"`
 	Pattern     string `yaml:"pattern"`
+
+	// Remediation is the YAML form of guidance for findings produced by this rule, in the same
+	// Text/URL shape as scanner.Finding.Remediation. It is only parsed; nothing copies it onto
+	// findings.
+	Remediation *RemediationConfig `yaml:"remediation,omitempty"`
+
+	// ConfidenceThreshold names a scanner.Evidence.Confidence band ("low", "medium", "high")
+	// for this rule. It is only parsed; no code filters matches by it, so every match is
+	// reported whatever its confidence.
+	ConfidenceThreshold string `yaml:"confidence_threshold,omitempty"`
+}
+
+// RemediationConfig is the YAML shape of a RuleConfig's remediation guidance.
+type RemediationConfig struct {
+	Text string `yaml:"text,omitempty"`
+	URL  string `yaml:"url,omitempty"`
 }
 
 // LoadConfig loads the configuration from a file